@@ -0,0 +1,427 @@
+package goneric
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// StatsAccumulator calculates statistics of a stream of numbers without storing them.
+// Mean and variance are calculated via Welford's online algorithm so they are numerically stable,
+// and all results apart from Min/Max are float64 to avoid overflows.
+// Accumulators can be merged, so parts of the data can be summarised in separate goroutines.
+// Zero value is ready to use, it is safe for concurrent use
+type StatsAccumulator[T Number] struct {
+	lock  sync.Mutex
+	count int
+	sum   float64
+	mean  float64
+	// sum of squared differences from the mean
+	m2  float64
+	min T
+	max T
+}
+
+// Add adds values to the accumulator
+func (a *StatsAccumulator[T]) Add(values ...T) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for _, v := range values {
+		if a.count == 0 || v < a.min {
+			a.min = v
+		}
+		if a.count == 0 || v > a.max {
+			a.max = v
+		}
+		a.count++
+		f := float64(v)
+		a.sum += f
+		delta := f - a.mean
+		a.mean += delta / float64(a.count)
+		a.m2 += delta * (f - a.mean)
+	}
+}
+
+// AddChan adds every value from the channel to the accumulator, returning after channel is closed
+func (a *StatsAccumulator[T]) AddChan(inCh chan T) {
+	for v := range inCh {
+		a.Add(v)
+	}
+}
+
+// Merge adds statistics from other accumulator to this one. Other accumulator is unchanged
+func (a *StatsAccumulator[T]) Merge(other *StatsAccumulator[T]) {
+	// copy the other one first so merging two accumulators into each other concurrently can't deadlock
+	other.lock.Lock()
+	count, sum, mean, m2, min, max := other.count, other.sum, other.mean, other.m2, other.min, other.max
+	other.lock.Unlock()
+	if count == 0 {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.count == 0 || min < a.min {
+		a.min = min
+	}
+	if a.count == 0 || max > a.max {
+		a.max = max
+	}
+	total := a.count + count
+	delta := mean - a.mean
+	a.m2 += m2 + delta*delta*float64(a.count)*float64(count)/float64(total)
+	a.mean += delta * float64(count) / float64(total)
+	a.sum += sum
+	a.count = total
+}
+
+// Count returns number of values added
+func (a *StatsAccumulator[T]) Count() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.count
+}
+
+// Sum returns sum of values
+func (a *StatsAccumulator[T]) Sum() float64 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.sum
+}
+
+// Min returns smallest value, zero if nothing was added
+func (a *StatsAccumulator[T]) Min() T {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.min
+}
+
+// Max returns biggest value, zero if nothing was added
+func (a *StatsAccumulator[T]) Max() T {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.max
+}
+
+// Mean returns average of values, NaN if nothing was added
+func (a *StatsAccumulator[T]) Mean() float64 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.count == 0 {
+		return math.NaN()
+	}
+	return a.mean
+}
+
+// Variance returns population variance, NaN if nothing was added
+func (a *StatsAccumulator[T]) Variance() float64 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.m2 / float64(a.count)
+}
+
+// VarianceSample returns sample variance (with Bessel's correction), NaN if less than 2 values were added
+func (a *StatsAccumulator[T]) VarianceSample() float64 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.count < 2 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.count-1)
+}
+
+// StdDev returns population standard deviation, NaN if nothing was added
+func (a *StatsAccumulator[T]) StdDev() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// StdDevSample returns sample standard deviation (with Bessel's correction), NaN if less than 2 values were added
+func (a *StatsAccumulator[T]) StdDevSample() float64 {
+	return math.Sqrt(a.VarianceSample())
+}
+
+// QuantileP2 estimates single quantile of a stream of numbers in constant memory,
+// using P² algorithm (Jain & Chlamtac, 1985). Estimate is exact for up to 5 values.
+// Unlike StatsAccumulator it can't be merged, use QuantileSketch for that.
+// It has to be created via NewQuantileP2, using zero value will panic.
+// It is safe for concurrent use
+type QuantileP2[T Number] struct {
+	lock sync.Mutex
+	p    float64
+	// first 5 values, until markers are initialized
+	initial []float64
+	count   int
+	// marker heights, positions, desired positions and desired position increments
+	q  [5]float64
+	n  [5]float64
+	np [5]float64
+	dn [5]float64
+}
+
+// NewQuantileP2 creates estimator of q-th quantile (0 <= q <= 1)
+// will panic on q outside of 0-1 range
+func NewQuantileP2[T Number](q float64) *QuantileP2[T] {
+	if q < 0 || q > 1 {
+		panic("RTFM")
+	}
+	return &QuantileP2[T]{
+		p:       q,
+		initial: make([]float64, 0, 5),
+		dn:      [5]float64{0, q / 2, q, (1 + q) / 2, 1},
+	}
+}
+
+// Add adds values to the estimator
+func (e *QuantileP2[T]) Add(values ...T) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	// zero value doesn't know which quantile to track
+	if e.initial == nil {
+		panic("RTFM")
+	}
+	for _, v := range values {
+		e.add(float64(v))
+	}
+}
+
+// AddChan adds every value from the channel to the estimator, returning after channel is closed
+func (e *QuantileP2[T]) AddChan(inCh chan T) {
+	for v := range inCh {
+		e.Add(v)
+	}
+}
+
+// Count returns number of values added
+func (e *QuantileP2[T]) Count() int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.count
+}
+
+// Quantile returns current estimate, NaN if nothing was added
+func (e *QuantileP2[T]) Quantile() float64 {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.count == 0 {
+		return math.NaN()
+	}
+	if e.count <= 5 {
+		return QuantileF64(e.p, QuantileLinear, e.initial...)
+	}
+	return e.q[2]
+}
+
+func (e *QuantileP2[T]) add(x float64) {
+	e.count++
+	if e.count <= 5 {
+		e.initial = append(e.initial, x)
+		if e.count == 5 {
+			c := slices.Clone(e.initial)
+			slices.Sort(c)
+			copy(e.q[:], c)
+			e.n = [5]float64{0, 1, 2, 3, 4}
+			e.np = [5]float64{0, 2 * e.p, 4 * e.p, 2 + 2*e.p, 4}
+		}
+		return
+	}
+	// find cell k such that q[k] <= x < q[k+1], extending extremes if needed
+	var k int
+	switch {
+	case x < e.q[0]:
+		e.q[0] = x
+		k = 0
+	case x >= e.q[4]:
+		e.q[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3; k++ {
+			if x < e.q[k+1] {
+				break
+			}
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		e.n[i]++
+	}
+	for i := range e.np {
+		e.np[i] += e.dn[i]
+	}
+	// adjust heights of middle markers if they are off their desired position
+	for i := 1; i < 4; i++ {
+		d := e.np[i] - e.n[i]
+		if (d >= 1 && e.n[i+1]-e.n[i] > 1) || (d <= -1 && e.n[i-1]-e.n[i] < -1) {
+			d = math.Copysign(1, d)
+			qp := e.parabolic(i, d)
+			if e.q[i-1] < qp && qp < e.q[i+1] {
+				e.q[i] = qp
+			} else {
+				e.q[i] = e.linear(i, d)
+			}
+			e.n[i] += d
+		}
+	}
+}
+
+func (e *QuantileP2[T]) parabolic(i int, d float64) float64 {
+	return e.q[i] + d/(e.n[i+1]-e.n[i-1])*
+		((e.n[i]-e.n[i-1]+d)*(e.q[i+1]-e.q[i])/(e.n[i+1]-e.n[i])+
+			(e.n[i+1]-e.n[i]-d)*(e.q[i]-e.q[i-1])/(e.n[i]-e.n[i-1]))
+}
+
+func (e *QuantileP2[T]) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return e.q[i] + d*(e.q[j]-e.q[i])/(e.n[j]-e.n[i])
+}
+
+// quantileSketchDefaultK is QuantileSketch accuracy parameter used when none was set
+const quantileSketchDefaultK = 200
+
+// QuantileSketch estimates any quantile of a stream of numbers in bounded memory, using compactor hierarchy
+// in the spirit of KLL sketch (Karnin, Lang & Liberty, 2016). Rank error is roughly proportional to 1/k.
+// Unlike QuantileP2 sketches can be merged, so parts of the data can be summarised in separate goroutines.
+// Estimate is exact until k values are added.
+// Zero value is ready to use with default k of 200, it is safe for concurrent use
+type QuantileSketch[T Number] struct {
+	lock  sync.Mutex
+	k     int
+	count int
+	// values at level i represent 2^i original values each
+	levels [][]float64
+}
+
+// NewQuantileSketch creates QuantileSketch keeping up to k values per level. Higher k means better accuracy and more memory
+// will panic if k is less than 2
+func NewQuantileSketch[T Number](k int) *QuantileSketch[T] {
+	if k < 2 {
+		panic("RTFM")
+	}
+	return &QuantileSketch[T]{k: k}
+}
+
+// Add adds values to the sketch
+func (s *QuantileSketch[T]) Add(values ...T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.init()
+	for _, v := range values {
+		s.levels[0] = append(s.levels[0], float64(v))
+		s.count++
+		if len(s.levels[0]) >= s.k {
+			s.compact(0)
+		}
+	}
+}
+
+// AddChan adds every value from the channel to the sketch, returning after channel is closed
+func (s *QuantileSketch[T]) AddChan(inCh chan T) {
+	for v := range inCh {
+		s.Add(v)
+	}
+}
+
+// Merge adds values summarised by other sketch to this one. Other sketch is unchanged
+func (s *QuantileSketch[T]) Merge(other *QuantileSketch[T]) {
+	// copy the other one first so merging two sketches into each other concurrently can't deadlock
+	other.lock.Lock()
+	count := other.count
+	levels := make([][]float64, len(other.levels))
+	for i, l := range other.levels {
+		levels[i] = slices.Clone(l)
+	}
+	other.lock.Unlock()
+	if count == 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.init()
+	for i, l := range levels {
+		for len(s.levels) <= i {
+			s.levels = append(s.levels, nil)
+		}
+		s.levels[i] = append(s.levels[i], l...)
+	}
+	s.count += count
+	for i := 0; i < len(s.levels); i++ {
+		if len(s.levels[i]) >= s.k {
+			s.compact(i)
+		}
+	}
+}
+
+// Count returns number of values added
+func (s *QuantileSketch[T]) Count() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.count
+}
+
+// Quantile returns estimate of q-th quantile (0 <= q <= 1), NaN if nothing was added
+// will panic on q outside of 0-1 range
+func (s *QuantileSketch[T]) Quantile(q float64) float64 {
+	if q < 0 || q > 1 {
+		panic("RTFM")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.count == 0 {
+		return math.NaN()
+	}
+	if len(s.levels) == 1 {
+		// nothing was compacted yet so all values are still there
+		return QuantileF64(q, QuantileLinear, s.levels[0]...)
+	}
+	type weighted struct {
+		v      float64
+		weight int
+	}
+	var items []weighted
+	total := 0
+	for i, l := range s.levels {
+		for _, v := range l {
+			items = append(items, weighted{v: v, weight: 1 << i})
+			total += 1 << i
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].v < items[j].v })
+	rank := q * float64(total-1)
+	cumulative := 0
+	for _, it := range items {
+		cumulative += it.weight
+		if float64(cumulative) > rank {
+			return it.v
+		}
+	}
+	return items[len(items)-1].v
+}
+
+func (s *QuantileSketch[T]) init() {
+	if s.k == 0 {
+		s.k = quantileSketchDefaultK
+	}
+	if len(s.levels) == 0 {
+		s.levels = [][]float64{make([]float64, 0, s.k)}
+	}
+}
+
+// compact halves the level by sorting it and promoting every other value, starting at random offset, to the level above
+func (s *QuantileSketch[T]) compact(level int) {
+	for ; level < len(s.levels) && len(s.levels[level]) >= s.k; level++ {
+		l := s.levels[level]
+		slices.Sort(l)
+		// odd value out stays on this level so total weight is kept
+		var leftover []float64
+		if len(l)%2 == 1 {
+			leftover = []float64{l[len(l)-1]}
+			l = l[:len(l)-1]
+		}
+		if level+1 == len(s.levels) {
+			s.levels = append(s.levels, nil)
+		}
+		for i := rand.IntN(2); i < len(l); i += 2 {
+			s.levels[level+1] = append(s.levels[level+1], l[i])
+		}
+		s.levels[level] = append(l[:0], leftover...)
+	}
+}
@@ -0,0 +1,157 @@
+package goneric
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestStatsAccumulator(t *testing.T) {
+	a := StatsAccumulator[int]{}
+	assert.Equal(t, 0, a.Count())
+	assert.True(t, math.IsNaN(a.Mean()))
+	assert.True(t, math.IsNaN(a.Variance()))
+	assert.True(t, math.IsNaN(a.VarianceSample()))
+
+	n := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	a.Add(n...)
+	assert.Equal(t, 8, a.Count())
+	assert.Equal(t, 40.0, a.Sum())
+	assert.Equal(t, 2, a.Min())
+	assert.Equal(t, 9, a.Max())
+	assert.Equal(t, 5.0, a.Mean())
+	assert.InDelta(t, VarianceF64(n...), a.Variance(), 1e-9)
+	assert.InDelta(t, VarianceSampleF64(n...), a.VarianceSample(), 1e-9)
+	assert.InDelta(t, StdDevF64(n...), a.StdDev(), 1e-9)
+	assert.InDelta(t, StdDevSampleF64(n...), a.StdDevSample(), 1e-9)
+
+	// no overflow on small ints
+	u := StatsAccumulator[uint8]{}
+	u.Add(200, 200, 250)
+	assert.Equal(t, 650.0, u.Sum())
+	assert.Equal(t, uint8(200), u.Min())
+	assert.Equal(t, uint8(250), u.Max())
+}
+
+func TestStatsAccumulatorChan(t *testing.T) {
+	a := StatsAccumulator[float64]{}
+	a.AddChan(GenChanN(func(idx int) float64 { return float64(idx) }, 101, true))
+	assert.Equal(t, 101, a.Count())
+	assert.Equal(t, 50.0, a.Mean())
+	assert.Equal(t, 100.0, a.Max())
+}
+
+func TestStatsAccumulatorMerge(t *testing.T) {
+	data := GenSlice(1000, func(idx int) float64 { return rand.NormFloat64()*10 + 100 })
+	parts := SliceChunk(data, 100)
+	total := StatsAccumulator[float64]{}
+	// summarise each part in parallel then merge
+	for _, acc := range ParallelMapSlice(func(part []float64) *StatsAccumulator[float64] {
+		a := &StatsAccumulator[float64]{}
+		a.Add(part...)
+		return a
+	}, 4, parts) {
+		total.Merge(acc)
+	}
+	empty := StatsAccumulator[float64]{}
+	total.Merge(&empty)
+	assert.Equal(t, 1000, total.Count())
+	assert.InDelta(t, SumF64(data...), total.Sum(), 1e-6)
+	assert.InDelta(t, AvgF64F64(data...), total.Mean(), 1e-9)
+	assert.InDelta(t, VarianceF64(data...), total.Variance(), 1e-6)
+	min, max := MinMax(data...)
+	assert.Equal(t, min, total.Min())
+	assert.Equal(t, max, total.Max())
+
+	// merging into empty one
+	e := StatsAccumulator[float64]{}
+	e.Merge(&total)
+	assert.Equal(t, total.Min(), e.Min())
+	assert.InDelta(t, total.Mean(), e.Mean(), 1e-9)
+}
+
+func TestQuantileP2(t *testing.T) {
+	e := NewQuantileP2[float64](0.5)
+	assert.True(t, math.IsNaN(e.Quantile()))
+	e.Add(3, 1, 2)
+	assert.Equal(t, 2.0, e.Quantile(), "should be exact for few values")
+	assert.Equal(t, 3, e.Count())
+
+	for _, q := range []float64{0.1, 0.5, 0.9, 0.99} {
+		e := NewQuantileP2[float64](q)
+		data := GenSlice(10000, func(idx int) float64 { return rand.Float64() * 1000 })
+		e.Add(data...)
+		assert.InDelta(t, QuantileF64(q, QuantileLinear, data...), e.Quantile(), 20, "quantile %f", q)
+	}
+
+	ch := NewQuantileP2[int](0.9)
+	ch.AddChan(GenChanN(func(idx int) int { return idx }, 1001, true))
+	assert.InDelta(t, 900, ch.Quantile(), 5)
+	assert.Panics(t, func() { NewQuantileP2[int](2) })
+	zero := QuantileP2[int]{}
+	assert.Panics(t, func() { zero.Add(1) }, "zero value should not be usable")
+}
+
+func TestQuantileSketch(t *testing.T) {
+	s := QuantileSketch[int]{}
+	assert.True(t, math.IsNaN(s.Quantile(0.5)))
+	s.Add(5, 1, 4, 2, 3)
+	assert.Equal(t, 3.0, s.Quantile(0.5), "should be exact for few values")
+	assert.Equal(t, 1.0, s.Quantile(0))
+	assert.Equal(t, 5.0, s.Quantile(1))
+	assert.Equal(t, 5, s.Count())
+
+	data := GenSlice(100000, func(idx int) float64 { return rand.Float64() * 1000 })
+	big := NewQuantileSketch[float64](200)
+	big.Add(data...)
+	assert.Equal(t, 100000, big.Count())
+	for _, q := range []float64{0, 0.1, 0.5, 0.9, 0.99, 1} {
+		assert.InDelta(t, QuantileF64(q, QuantileLinear, data...), big.Quantile(q), 20, "quantile %f", q)
+	}
+
+	ch := QuantileSketch[int]{}
+	ch.AddChan(GenChanN(func(idx int) int { return idx }, 1001, true))
+	assert.InDelta(t, 900, ch.Quantile(0.9), 20)
+	assert.Panics(t, func() { ch.Quantile(1.5) })
+	assert.Panics(t, func() { NewQuantileSketch[int](1) })
+}
+
+func TestQuantileSketchMerge(t *testing.T) {
+	data := GenSlice(100000, func(idx int) float64 { return rand.NormFloat64()*10 + 100 })
+	total := NewQuantileSketch[float64](200)
+	for _, s := range ParallelMapSlice(func(part []float64) *QuantileSketch[float64] {
+		s := NewQuantileSketch[float64](200)
+		s.Add(part...)
+		return s
+	}, 4, SliceChunk(data, 10000)) {
+		total.Merge(s)
+	}
+	total.Merge(&QuantileSketch[float64]{})
+	assert.Equal(t, 100000, total.Count())
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+	for _, q := range []float64{0.01, 0.5, 0.99} {
+		// compare ranks, not values, as values are sparse at the tails
+		rank, _ := slices.BinarySearch(sorted, total.Quantile(q))
+		assert.InDelta(t, q, float64(rank)/float64(len(sorted)), 0.02, "quantile %f", q)
+	}
+
+	// merging small ones keeps them exact
+	a, b := QuantileSketch[int]{}, QuantileSketch[int]{}
+	a.Add(1, 2)
+	b.Add(3, 4, 5)
+	a.Merge(&b)
+	assert.Equal(t, 3.0, a.Quantile(0.5))
+	assert.Equal(t, 3, b.Count(), "other sketch should be unchanged")
+}
+
+func ExampleStatsAccumulator() {
+	latency := GenChanN(func(idx int) int { return []int{12, 15, 11, 40, 13}[idx] }, 5, true)
+	stats := StatsAccumulator[int]{}
+	stats.AddChan(latency)
+	fmt.Printf("n=%d min=%d max=%d mean=%.1f", stats.Count(), stats.Min(), stats.Max(), stats.Mean())
+	// Output: n=5 min=11 max=40 mean=18.2
+}
@@ -1,6 +1,9 @@
 package goneric
 
-import "sync"
+import (
+	"errors"
+	"sync"
+)
 
 // Async runs a function in goroutine and returns pipe with result
 func Async[T1 any](f func() T1) chan T1 {
@@ -53,6 +56,83 @@ func AsyncVUnpanic[T1 any](funcList ...func() T1) chan T1 {
 	return out
 }
 
+// AsyncVIndexed runs a number of functions in goroutines and returns pipe with results tagged with function index,
+// then closes after goroutines finish
+// order is not guaranteed
+func AsyncVIndexed[T1 any](funcList ...func() T1) chan ValueIndex[T1] {
+	return AsyncVIndexedLimit(len(funcList), funcList...)
+}
+
+// AsyncVIndexedLimit works like AsyncVIndexed but runs at most `concurrency` functions at once
+// will panic if concurrency is less than 1
+func AsyncVIndexedLimit[T1 any](concurrency int, funcList ...func() T1) chan ValueIndex[T1] {
+	out := make(chan ValueIndex[T1], 1)
+	if len(funcList) == 0 {
+		close(out)
+		return out
+	}
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	limit := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	wg.Add(len(funcList))
+	go func() {
+		for idx, f := range funcList {
+			limit <- struct{}{}
+			go func() {
+				defer wg.Done()
+				out <- ValueIndex[T1]{V: f(), IDX: idx}
+				<-limit
+			}()
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// AsyncVErr runs a number of functions in goroutines, waits for all of them and returns results in order of functions.
+// Failed ones have zero value in the result and their errors are returned joined via errors.Join in order of functions.
+// Panics are recovered and returned as *PanicError with function index as a Key
+func AsyncVErr[T1 any](funcList ...func() (T1, error)) ([]T1, error) {
+	return AsyncVErrLimit(len(funcList), funcList...)
+}
+
+// AsyncVErrLimit works like AsyncVErr but runs at most `concurrency` functions at once
+// will panic if concurrency is less than 1
+func AsyncVErrLimit[T1 any](concurrency int, funcList ...func() (T1, error)) ([]T1, error) {
+	type result struct {
+		v   T1
+		err error
+	}
+	wrapped := make([]func() result, len(funcList))
+	for idx, f := range funcList {
+		wrapped[idx] = func() result {
+			res, err := unpanic(idx, func(struct{}) result {
+				v, err := f()
+				return result{v: v, err: err}
+			}, struct{}{})
+			if err != nil {
+				return result{err: err}
+			}
+			return res
+		}
+	}
+	out := make([]T1, len(funcList))
+	errs := make([]error, len(funcList))
+	for r := range AsyncVIndexedLimit(concurrency, wrapped...) {
+		if r.V.err != nil {
+			errs[r.IDX] = r.V.err
+		} else {
+			out[r.IDX] = r.V.v
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
 // AsyncPipe takes a single value from input channel, runs it thru function in goroutine
 // and returns channel with the result
 func AsyncPipe[T1, T2 any](in chan T1, f func(T1) T2) chan T2 {
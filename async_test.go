@@ -1,10 +1,14 @@
 package goneric
 
 import (
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"sort"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"testing/synctest"
 	"time"
 )
 
@@ -89,3 +93,67 @@ func ExampleAsync() {
 	)
 	// Output: date: 1970-05-23 date: 1973-11-29
 }
+
+func TestAsyncVIndexed(t *testing.T) {
+	out := ChanToSlice(AsyncVIndexed(
+		func() string { return "a" },
+		func() string { return "b" },
+		func() string { return "c" },
+	))
+	sort.Slice(out, func(i, j int) bool { return out[i].IDX < out[j].IDX })
+	assert.Equal(t, []ValueIndex[string]{{V: "a", IDX: 0}, {V: "b", IDX: 1}, {V: "c", IDX: 2}}, out)
+	assert.Empty(t, ChanToSlice(AsyncVIndexed[int]()))
+	assert.Panics(t, func() { AsyncVIndexedLimit(0, func() int { return 1 }) })
+}
+
+func TestAsyncVIndexedLimit(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var running, maxRunning atomic.Int64
+		f := func() int {
+			n := running.Add(1)
+			for m := maxRunning.Load(); n > m && !maxRunning.CompareAndSwap(m, n); m = maxRunning.Load() {
+			}
+			time.Sleep(time.Second)
+			running.Add(-1)
+			return 1
+		}
+		start := time.Now()
+		out := ChanToSlice(AsyncVIndexedLimit(2, f, f, f, f, f))
+		assert.Len(t, out, 5)
+		assert.Equal(t, int64(2), maxRunning.Load())
+		assert.Equal(t, time.Second*3, time.Since(start))
+	})
+}
+
+func TestAsyncVErr(t *testing.T) {
+	errFail := errors.New("fail")
+	out, err := AsyncVErr(
+		func() (int, error) { return 1, nil },
+		func() (int, error) { return 2, errFail },
+		func() (int, error) { panic("third") },
+		func() (int, error) { return 4, nil },
+	)
+	assert.Equal(t, []int{1, 0, 0, 4}, out)
+	assert.ErrorIs(t, err, errFail)
+	var perr *PanicError
+	assert.ErrorAs(t, err, &perr)
+	assert.Equal(t, 2, perr.Key)
+
+	out, err = AsyncVErr[int]()
+	assert.NoError(t, err)
+	assert.Empty(t, out)
+
+	synctest.Test(t, func(t *testing.T) {
+		f := func(v int) func() (int, error) {
+			return func() (int, error) {
+				time.Sleep(time.Second)
+				return v, nil
+			}
+		}
+		start := time.Now()
+		out, err := AsyncVErrLimit(2, f(1), f(2), f(3))
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, out)
+		assert.Equal(t, time.Second*2, time.Since(start))
+	})
+}
@@ -0,0 +1,104 @@
+package goneric
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff decides how long to wait between retries
+type Backoff interface {
+	// Delay returns how long to wait before retry after failed attempt number `attempt` (starting from 1).
+	// `previous` is the delay returned for the previous attempt, 0 for the first one
+	Delay(attempt int, previous time.Duration) time.Duration
+}
+
+// BackoffConstant waits the same interval between every retry
+type BackoffConstant struct {
+	Interval time.Duration
+}
+
+func (b BackoffConstant) Delay(attempt int, previous time.Duration) time.Duration {
+	return b.Interval
+}
+
+// BackoffLinear waits Initial, then increases the wait by Step on every retry, up to Max (0 means no limit)
+type BackoffLinear struct {
+	Initial time.Duration
+	Step    time.Duration
+	Max     time.Duration
+}
+
+func (b BackoffLinear) Delay(attempt int, previous time.Duration) time.Duration {
+	return capDelay(b.Initial+b.Step*time.Duration(attempt-1), b.Max)
+}
+
+// BackoffExponential waits Initial, then multiplies the wait by Multiplier (2 if not set) on every retry, up to Max (0 means no limit)
+type BackoffExponential struct {
+	Initial    time.Duration
+	Multiplier float64
+	Max        time.Duration
+}
+
+func (b BackoffExponential) Delay(attempt int, previous time.Duration) time.Duration {
+	m := b.Multiplier
+	if m == 0 {
+		m = 2
+	}
+	return capDelay(scaleDelay(b.Initial, math.Pow(m, float64(attempt-1))), b.Max)
+}
+
+// BackoffFullJitter waits random interval between 0 and exponentially growing ceiling of Base*2^(attempt-1), capped at Max (0 means no limit)
+// Spreads retries of many clients evenly, see "Exponential Backoff And Jitter" from AWS architecture blog
+type BackoffFullJitter struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b BackoffFullJitter) Delay(attempt int, previous time.Duration) time.Duration {
+	ceiling := capDelay(scaleDelay(b.Base, math.Pow(2, float64(attempt-1))), b.Max)
+	return randDelay(0, ceiling)
+}
+
+// BackoffDecorrelatedJitter waits random interval between Base and 3 times the previous wait, capped at Max (0 means no limit)
+// Grows like exponential backoff but with delays decorrelated from each other,
+// see "Exponential Backoff And Jitter" from AWS architecture blog
+type BackoffDecorrelatedJitter struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b BackoffDecorrelatedJitter) Delay(attempt int, previous time.Duration) time.Duration {
+	previous = max(previous, b.Base)
+	return capDelay(randDelay(b.Base, scaleDelay(previous, 3)), b.Max)
+}
+
+// capDelay limits delay to max, 0 means no limit
+func capDelay(d time.Duration, max time.Duration) time.Duration {
+	if max > 0 && d > max {
+		return max
+	}
+	return d
+}
+
+// scaleDelay multiplies delay, saturating instead of overflowing
+func scaleDelay(d time.Duration, factor float64) time.Duration {
+	f := float64(d) * factor
+	if f >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(f)
+}
+
+// randDelay returns random delay in [min, max] range
+func randDelay(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	span := max - min
+	// range is inclusive, but saturated delays would overflow when adding 1
+	if span < math.MaxInt64 {
+		span++
+	}
+	return min + rand.N(span)
+}
@@ -0,0 +1,82 @@
+package goneric
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func delays(b Backoff, n int) []time.Duration {
+	out := make([]time.Duration, n)
+	var prev time.Duration
+	for i := range out {
+		prev = b.Delay(i+1, prev)
+		out[i] = prev
+	}
+	return out
+}
+
+func TestBackoffConstant(t *testing.T) {
+	assert.Equal(t,
+		[]time.Duration{time.Second, time.Second, time.Second},
+		delays(BackoffConstant{Interval: time.Second}, 3))
+}
+
+func TestBackoffLinear(t *testing.T) {
+	assert.Equal(t,
+		[]time.Duration{10, 15, 20, 25, 25},
+		delays(BackoffLinear{Initial: 10, Step: 5, Max: 25}, 5))
+	assert.Equal(t,
+		[]time.Duration{10, 20, 30},
+		delays(BackoffLinear{Initial: 10, Step: 10}, 3))
+}
+
+func TestBackoffExponential(t *testing.T) {
+	assert.Equal(t,
+		[]time.Duration{10, 20, 40, 80, 100},
+		delays(BackoffExponential{Initial: 10, Max: 100}, 5))
+	assert.Equal(t,
+		[]time.Duration{10, 15, 22},
+		delays(BackoffExponential{Initial: 10, Multiplier: 1.5}, 3))
+	// does not overflow
+	assert.Equal(t, time.Duration(math.MaxInt64), BackoffExponential{Initial: time.Hour}.Delay(200, 0))
+}
+
+func TestBackoffFullJitter(t *testing.T) {
+	b := BackoffFullJitter{Base: 100, Max: 1000}
+	for i := 0; i < 100; i++ {
+		for attempt, ceiling := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+			d := b.Delay(attempt+1, 0)
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, ceiling)
+		}
+	}
+	// with enough samples it should not be constant
+	assert.Greater(t, len(SliceDedupe(delays(BackoffFullJitter{Base: time.Second}, 20))), 1)
+}
+
+func TestBackoffDecorrelatedJitter(t *testing.T) {
+	b := BackoffDecorrelatedJitter{Base: 100, Max: 1000}
+	for i := 0; i < 100; i++ {
+		var prev time.Duration
+		for attempt := 1; attempt < 10; attempt++ {
+			d := b.Delay(attempt, prev)
+			assert.GreaterOrEqual(t, d, time.Duration(100))
+			assert.LessOrEqual(t, d, Min(time.Duration(1000), max(prev, 100)*3))
+			prev = d
+		}
+	}
+}
+
+func TestBackoffJitterUnlimited(t *testing.T) {
+	// ceiling saturates with no Max set, that should not overflow the random range
+	for _, attempt := range []int{40, 64, 1000} {
+		d := BackoffFullJitter{Base: time.Second}.Delay(attempt, 0)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+	}
+	d := BackoffDecorrelatedJitter{}.Delay(1, time.Duration(math.MaxInt64))
+	assert.GreaterOrEqual(t, d, time.Duration(0))
+	d = BackoffDecorrelatedJitter{Base: time.Second}.Delay(100, time.Duration(math.MaxInt64))
+	assert.GreaterOrEqual(t, d, time.Second)
+}
@@ -0,0 +1,152 @@
+package goneric
+
+import "sync"
+
+// BroadcastPolicy decides what Broadcast does when subscriber's buffer is full
+type BroadcastPolicy int
+
+const (
+	// BroadcastBlock waits for subscriber to make room, slowing down every other subscriber and the input
+	BroadcastBlock BroadcastPolicy = iota
+	// BroadcastDropNewest drops the new element for that subscriber
+	BroadcastDropNewest
+	// BroadcastDropOldest drops the oldest buffered element of that subscriber to make room for the new one
+	BroadcastDropOldest
+)
+
+// Broadcast sends every element from input channel to all current subscribers,
+// each with its own buffer and policy for when it can't keep up, see ChanBroadcast
+type Broadcast[T any] struct {
+	lock   sync.Mutex
+	subs   map[*broadcastSub[T]]bool
+	closed bool
+}
+
+type broadcastSub[T any] struct {
+	ch     chan T
+	policy BroadcastPolicy
+	// closed on unsubscribe, to unblock pending send
+	done chan struct{}
+	// held while sending to ch, so it is never closed mid-send
+	lock   sync.Mutex
+	closed bool
+}
+
+// close closes subscriber's channel unless it already was, waiting for pending send to finish
+func (s *broadcastSub[T]) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// ChanBroadcast starts sending elements from input channel to subscribers of returned Broadcast.
+// Elements arriving while there are no subscribers are discarded.
+// close is propagated to every subscriber
+func ChanBroadcast[T any](in chan T) *Broadcast[T] {
+	b := &Broadcast[T]{subs: make(map[*broadcastSub[T]]bool)}
+	go func() {
+		var subs []*broadcastSub[T]
+		for v := range in {
+			subs = b.snapshot(subs)
+			for _, sub := range subs {
+				sub.send(v)
+			}
+		}
+		b.lock.Lock()
+		b.closed = true
+		subs = subs[:0]
+		for sub := range b.subs {
+			subs = append(subs, sub)
+		}
+		clear(b.subs)
+		b.lock.Unlock()
+		for _, sub := range subs {
+			sub.close()
+		}
+	}()
+	return b
+}
+
+// Subscribe returns channel receiving every element sent after subscribing, buffering up to `buffer` elements,
+// and function that unsubscribes and closes the channel. Calling unsubscribe more than once is safe.
+// Subscribing after input was closed returns closed channel
+func (b *Broadcast[T]) Subscribe(buffer int, policy BroadcastPolicy) (out chan T, unsubscribe func()) {
+	sub := &broadcastSub[T]{
+		ch:     make(chan T, buffer),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	b.subs[sub] = true
+	once := sync.Once{}
+	return sub.ch, func() {
+		once.Do(func() {
+			close(sub.done)
+			b.lock.Lock()
+			delete(b.subs, sub)
+			b.lock.Unlock()
+			sub.close()
+		})
+	}
+}
+
+// Subscribers returns current number of subscribers
+func (b *Broadcast[T]) Subscribers() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.subs)
+}
+
+// snapshot copies current subscribers into buf, so sending to them doesn't need to hold the lock
+func (b *Broadcast[T]) snapshot(buf []*broadcastSub[T]) []*broadcastSub[T] {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	clear(buf)
+	buf = buf[:0]
+	for sub := range b.subs {
+		buf = append(buf, sub)
+	}
+	return buf
+}
+
+func (s *broadcastSub[T]) send(v T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return
+	}
+	switch s.policy {
+	case BroadcastDropNewest:
+		select {
+		case s.ch <- v:
+		default:
+		}
+	case BroadcastDropOldest:
+		select {
+		case s.ch <- v:
+		default:
+			// subscriber might have read it in the meantime, so don't block on either
+			select {
+			case <-s.ch:
+			default:
+			}
+			select {
+			case s.ch <- v:
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- v:
+		case <-s.done:
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package goneric
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/synctest"
+)
+
+func TestChanBroadcast(t *testing.T) {
+	in := make(chan int)
+	b := ChanBroadcast(in)
+	s1, _ := b.Subscribe(0, BroadcastBlock)
+	s2, _ := b.Subscribe(10, BroadcastBlock)
+	assert.Equal(t, 2, b.Subscribers())
+	go func() {
+		for i := range 5 {
+			in <- i
+		}
+		close(in)
+	}()
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, ChanToSlice(s1))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, ChanToSlice(s2))
+	late, unsubscribe := b.Subscribe(1, BroadcastBlock)
+	_, ok := <-late
+	assert.False(t, ok, "subscribing after close should return closed channel")
+	unsubscribe()
+}
+
+func TestChanBroadcastPolicies(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		in := make(chan int)
+		b := ChanBroadcast(in)
+		newest, _ := b.Subscribe(2, BroadcastDropNewest)
+		oldest, _ := b.Subscribe(2, BroadcastDropOldest)
+		blocking, _ := b.Subscribe(5, BroadcastBlock)
+		for i := range 5 {
+			in <- i
+		}
+		synctest.Wait()
+		close(in)
+		assert.Equal(t, []int{0, 1}, ChanToSlice(newest))
+		assert.Equal(t, []int{3, 4}, ChanToSlice(oldest))
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, ChanToSlice(blocking))
+	})
+}
+
+func TestChanBroadcastUnsubscribe(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		in := make(chan int)
+		b := ChanBroadcast(in)
+		stuck, unsubscribeStuck := b.Subscribe(0, BroadcastBlock)
+		active, unsubscribe := b.Subscribe(10, BroadcastBlock)
+		in <- 1
+		synctest.Wait()
+		// broadcast is blocked on subscriber that doesn't read, unsubscribing should unblock it
+		unsubscribeStuck()
+		unsubscribeStuck()
+		_, ok := <-stuck
+		assert.False(t, ok)
+		in <- 2
+		synctest.Wait()
+		assert.Equal(t, 1, b.Subscribers())
+		assert.Equal(t, 1, <-active)
+		assert.Equal(t, 2, <-active)
+		unsubscribe()
+		_, ok = <-active
+		assert.False(t, ok)
+		assert.Equal(t, 0, b.Subscribers())
+		// no subscribers, elements are discarded
+		in <- 3
+		close(in)
+	})
+}
+
+func TestChanBroadcastBlockedSubscriber(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		in := make(chan int)
+		b := ChanBroadcast(in)
+		slow, unsubscribeSlow := b.Subscribe(0, BroadcastBlock)
+		in <- 1
+		synctest.Wait()
+		// broadcast is blocked on slow subscriber, that should not block managing subscriptions
+		assert.Equal(t, 1, b.Subscribers())
+		late, unsubscribeLate := b.Subscribe(1, BroadcastBlock)
+		assert.Equal(t, 2, b.Subscribers())
+		unsubscribeLate()
+		_, ok := <-late
+		assert.False(t, ok)
+		// slow subscriber reads one element then unsubscribes itself from its own goroutine
+		got := make(chan []int, 1)
+		go func() {
+			var out []int
+			for v := range slow {
+				out = append(out, v)
+				unsubscribeSlow()
+			}
+			got <- out
+		}()
+		assert.Equal(t, []int{1}, <-got)
+		in <- 2
+		close(in)
+		synctest.Wait()
+		assert.Equal(t, 0, b.Subscribers())
+	})
+}
@@ -1,6 +1,9 @@
 package goneric
 
 import (
+	"context"
+	"hash/maphash"
+	"sync"
 	"time"
 )
 
@@ -30,8 +33,13 @@ func ChanToSliceN[T any](inCh chan T, n int) []T {
 // ChanToSliceNTimeout loads up to n elements from channel to slice,
 // until timeout expires or the channel is closed, whichever comes first
 func ChanToSliceNTimeout[T any](inCh chan T, n int, timeout time.Duration) []T {
+	return ChanToSliceNTimeoutClock(RealClock{}, inCh, n, timeout)
+}
+
+// ChanToSliceNTimeoutClock is ChanToSliceNTimeout using provided Clock for the timeout
+func ChanToSliceNTimeoutClock[T any](clock Clock, inCh chan T, n int, timeout time.Duration) []T {
 	s := make([]T, 0)
-	t := time.After(timeout)
+	t := clock.After(timeout)
 	for {
 		select {
 		case <-t:
@@ -60,3 +68,170 @@ func SliceToChan[T any](in []T, out chan T, closeOutputChan ...bool) {
 	}()
 	return
 }
+
+// ChanBatch groups elements from input channel into batches, sending batch when it reaches `maxSize`
+// or when `maxWait` passes since its first element, whichever comes first. `maxWait` of 0 means no time limit.
+// Remaining elements are sent as last batch after input is closed, empty batches are never sent.
+// close is propagated
+// will panic if maxSize is less than 1
+func ChanBatch[T any](in chan T, maxSize int, maxWait time.Duration) (out chan []T) {
+	return ChanBatchCtxClock(context.Background(), RealClock{}, in, maxSize, maxWait)
+}
+
+// ChanBatchClock is ChanBatch using provided Clock for waiting
+// will panic if maxSize is less than 1
+func ChanBatchClock[T any](clock Clock, in chan T, maxSize int, maxWait time.Duration) (out chan []T) {
+	return ChanBatchCtxClock(context.Background(), clock, in, maxSize, maxWait)
+}
+
+// ChanBatchCtx works like ChanBatch but stops on context cancellation, closing output channel
+// and discarding elements of unfinished batch
+// will panic if maxSize is less than 1
+func ChanBatchCtx[T any](ctx context.Context, in chan T, maxSize int, maxWait time.Duration) (out chan []T) {
+	return ChanBatchCtxClock(ctx, RealClock{}, in, maxSize, maxWait)
+}
+
+// ChanBatchCtxClock is ChanBatchCtx using provided Clock for waiting
+// will panic if maxSize is less than 1
+func ChanBatchCtxClock[T any](
+	ctx context.Context,
+	clock Clock,
+	in chan T,
+	maxSize int,
+	maxWait time.Duration,
+) (out chan []T) {
+	if maxSize < 1 {
+		panic("RTFM")
+	}
+	out = make(chan []T, 1)
+	go func() {
+		defer close(out)
+		batch := make([]T, 0, maxSize)
+		var timeout <-chan time.Time
+		flush := func() bool {
+			timeout = nil
+			if len(batch) == 0 {
+				return true
+			}
+			select {
+			case out <- batch:
+			case <-ctx.Done():
+				return false
+			}
+			batch = make([]T, 0, maxSize)
+			return true
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if len(batch) == 0 && maxWait > 0 {
+					timeout = clock.After(maxWait)
+				}
+				batch = append(batch, v)
+				if len(batch) >= maxSize && !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// ChanMerge sends elements from all input channels to single output channel (fan-in)
+// close is propagated after all inputs are closed
+func ChanMerge[T any](in ...chan T) (out chan T) {
+	out = make(chan T, 1)
+	wg := sync.WaitGroup{}
+	wg.Add(len(in))
+	for _, ch := range in {
+		go func() {
+			defer wg.Done()
+			for v := range ch {
+				out <- v
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// ChanTee sends every element from input channel to each of `n` output channels
+// all outputs need to be read or else it will stall
+// close is propagated
+// will panic if n is less than 1
+func ChanTee[T any](in chan T, n int) (out []chan T) {
+	out = makeChans[T](n)
+	go func() {
+		for v := range in {
+			for _, ch := range out {
+				ch <- v
+			}
+		}
+		closeChans(out)
+	}()
+	return out
+}
+
+// ChanRoundRobin distributes elements from input channel to `n` output channels in turn
+// all outputs need to be read or else it will stall
+// close is propagated
+// will panic if n is less than 1
+func ChanRoundRobin[T any](in chan T, n int) (out []chan T) {
+	out = makeChans[T](n)
+	go func() {
+		i := 0
+		for v := range in {
+			out[i] <- v
+			i = (i + 1) % n
+		}
+		closeChans(out)
+	}()
+	return out
+}
+
+// ChanSplit distributes elements from input channel to `n` output channels by key returned from keyFunc,
+// elements with same key always go to the same output, so each output can be processed by separate goroutine in order
+// all outputs need to be read or else it will stall
+// close is propagated
+// will panic if n is less than 1
+func ChanSplit[T any, K comparable](in chan T, n int, keyFunc func(T) K) (out []chan T) {
+	out = makeChans[T](n)
+	seed := maphash.MakeSeed()
+	go func() {
+		for v := range in {
+			out[maphash.Comparable(seed, keyFunc(v))%uint64(n)] <- v
+		}
+		closeChans(out)
+	}()
+	return out
+}
+
+func makeChans[T any](n int) []chan T {
+	if n < 1 {
+		panic("RTFM")
+	}
+	out := make([]chan T, n)
+	for i := range out {
+		out[i] = make(chan T, 1)
+	}
+	return out
+}
+
+func closeChans[T any](chans []chan T) {
+	for _, ch := range chans {
+		close(ch)
+	}
+}
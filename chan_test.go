@@ -1,7 +1,9 @@
 package goneric
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	"strconv"
 	"sync"
 	"testing"
 	"testing/synctest"
@@ -87,3 +89,138 @@ func TestSliceToChan(t *testing.T) {
 		assert.Panics(t, func() { close(ch) }, "make sure out channel is closed")
 	})
 }
+
+func TestChanToSliceNTimeoutClock(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	ch := make(chan int)
+	result := make(chan []int)
+	go func() {
+		result <- ChanToSliceNTimeoutClock(clock, ch, 10, time.Minute)
+	}()
+	ch <- 1
+	ch <- 2
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	assert.Equal(t, []int{1, 2}, <-result)
+}
+
+func TestChanMerge(t *testing.T) {
+	out := ChanMerge(GenSliceToChan([]int{1, 2, 3}, true), GenSliceToChan([]int{4, 5}, true), GenSliceToChan([]int{}, true))
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, ChanToSlice(out))
+	_, ok := <-ChanMerge[int]()
+	assert.False(t, ok, "no inputs should close immediately")
+}
+
+func TestChanTee(t *testing.T) {
+	outs := ChanTee(GenSliceToChan([]int{1, 2, 3}, true), 3)
+	assert.Len(t, outs, 3)
+	results := ParallelMapSlice(ChanToSlice[int], 3, outs)
+	for _, r := range results {
+		assert.Equal(t, []int{1, 2, 3}, r)
+	}
+	assert.Panics(t, func() { ChanTee(make(chan int), 0) })
+}
+
+func TestChanRoundRobin(t *testing.T) {
+	outs := ChanRoundRobin(GenSliceToChan([]int{1, 2, 3, 4, 5}, true), 2)
+	results := ParallelMapSlice(ChanToSlice[int], 2, outs)
+	assert.Equal(t, [][]int{{1, 3, 5}, {2, 4}}, results)
+}
+
+func TestChanSplit(t *testing.T) {
+	data := GenSlice(100, func(idx int) KeyValue[string, int] {
+		return KeyValue[string, int]{K: strconv.Itoa(idx % 7), V: idx}
+	})
+	outs := ChanSplit(GenSliceToChan(data, true), 3, func(v KeyValue[string, int]) string { return v.K })
+	results := ParallelMapSlice(ChanToSlice[KeyValue[string, int]], 3, outs)
+	total := 0
+	seen := map[string]int{}
+	for shard, r := range results {
+		total += len(r)
+		last := -1
+		for _, v := range r {
+			if s, ok := seen[v.K]; ok {
+				assert.Equal(t, s, shard, "same key should always go to same output")
+			}
+			seen[v.K] = shard
+			assert.Greater(t, v.V, last, "order should be kept within output")
+			last = v.V
+		}
+	}
+	assert.Equal(t, 100, total)
+}
+
+func TestChanBatch(t *testing.T) {
+	out := ChanBatch(GenSliceToChan([]int{1, 2, 3, 4, 5, 6, 7}, true), 3, 0)
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, ChanToSlice(out))
+	assert.Empty(t, ChanToSlice(ChanBatch(GenSliceToChan([]int{}, true), 3, time.Second)))
+	assert.Panics(t, func() { ChanBatch(make(chan int), 0, time.Second) })
+
+	synctest.Test(t, func(t *testing.T) {
+		in := make(chan int)
+		out := ChanBatch(in, 3, time.Second)
+		start := time.Now()
+		in <- 1
+		in <- 2
+		assert.Equal(t, []int{1, 2}, <-out)
+		assert.Equal(t, time.Second, time.Since(start))
+		close(in)
+	})
+}
+
+func TestChanBatchClock(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+		in := make(chan int)
+		out := ChanBatchClock(clock, in, 3, time.Second)
+		in <- 1
+		in <- 2
+		synctest.Wait()
+		clock.Advance(time.Second - time.Millisecond)
+		synctest.Wait()
+		select {
+		case <-out:
+			t.Error("batch sent before maxWait passed")
+		default:
+		}
+		// timer fires before batch is full
+		clock.Advance(time.Millisecond)
+		assert.Equal(t, []int{1, 2}, <-out)
+		clock.Advance(time.Minute)
+		// timer starts with first element of the batch, not when previous one was sent
+		in <- 3
+		synctest.Wait()
+		clock.Advance(time.Millisecond * 500)
+		in <- 4
+		in <- 5
+		assert.Equal(t, []int{3, 4, 5}, <-out)
+		in <- 6
+		synctest.Wait()
+		clock.Advance(time.Millisecond * 500)
+		synctest.Wait()
+		select {
+		case <-out:
+			t.Error("timer of previous batch should not flush the next one")
+		default:
+		}
+		close(in)
+		assert.Equal(t, []int{6}, <-out, "remainder should be flushed on close")
+		_, ok := <-out
+		assert.False(t, ok)
+	})
+}
+
+func TestChanBatchCtx(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan int)
+		out := ChanBatchCtx(ctx, in, 2, time.Second)
+		in <- 1
+		in <- 2
+		in <- 3
+		assert.Equal(t, []int{1, 2}, <-out)
+		cancel()
+		_, ok := <-out
+		assert.False(t, ok, "output should be closed on cancel")
+	})
+}
@@ -0,0 +1,158 @@
+package goneric
+
+import "math"
+
+// SumChecked sums numbers, returning ErrOverflow if result does not fit in the type
+// For floats overflow means getting infinity out of finite inputs
+func SumChecked[T Number](n ...T) (sum T, err error) {
+	for _, v := range n {
+		var overflow bool
+		sum, overflow = addChecked(sum, v)
+		if overflow {
+			return sum, ErrOverflow{}
+		}
+	}
+	return sum, nil
+}
+
+// SumSaturating sums numbers, clamping the result to the min/max value of integer type instead of overflowing.
+// Clamping happens after each addition, so the result depends on order:
+// `[MaxInt8, 1, -1]` gives MaxInt8-1, not MaxInt8.
+// Floats follow the usual IEEE rules and go to infinity
+func SumSaturating[T Number](n ...T) (sum T) {
+	min, max := numberLimits[T]()
+	for _, v := range n {
+		s, overflow := addChecked(sum, v)
+		switch {
+		case !overflow:
+			sum = s
+		case v > 0:
+			sum = max
+		default:
+			sum = min
+		}
+	}
+	return sum
+}
+
+// MulChecked multiplies numbers, returning ErrOverflow if result does not fit in the type
+// For floats overflow means getting infinity out of finite inputs. Returns 1 on empty input
+func MulChecked[T Number](n ...T) (product T, err error) {
+	product = 1
+	for _, v := range n {
+		var overflow bool
+		product, overflow = mulChecked(product, v)
+		if overflow {
+			return product, ErrOverflow{}
+		}
+	}
+	return product, nil
+}
+
+// MulSaturating multiplies numbers, clamping the result to the min/max value of integer type instead of overflowing.
+// Floats follow the usual IEEE rules and go to infinity. Returns 1 on empty input
+func MulSaturating[T Number](n ...T) (product T) {
+	min, max := numberLimits[T]()
+	product = 1
+	for _, v := range n {
+		p, overflow := mulChecked(product, v)
+		switch {
+		case !overflow:
+			product = p
+		case (product < 0) != (v < 0):
+			product = min
+		default:
+			product = max
+		}
+	}
+	return product
+}
+
+// AvgChecked calculates average, returning ErrOverflow if the sum does not fit in the type
+// and ErrEmpty on empty input instead of dividing by zero
+func AvgChecked[T Number](n ...T) (avg T, err error) {
+	if len(n) == 0 {
+		return avg, ErrEmpty{}
+	}
+	sum, err := SumChecked(n...)
+	if err != nil {
+		return avg, err
+	}
+	return sum / T(len(n)), nil
+}
+
+// MaxOk returns biggest number, or false on empty input instead of panicking
+func MaxOk[T Number](n ...T) (max T, ok bool) {
+	if len(n) == 0 {
+		return max, false
+	}
+	return Max(n...), true
+}
+
+// MinOk returns smallest number, or false on empty input instead of panicking
+func MinOk[T Number](n ...T) (min T, ok bool) {
+	if len(n) == 0 {
+		return min, false
+	}
+	return Min(n...), true
+}
+
+// MaxOr returns biggest number, or passed default value on empty input
+func MaxOr[T Number](def T, n ...T) T {
+	if len(n) == 0 {
+		return def
+	}
+	return Max(n...)
+}
+
+// MinOr returns smallest number, or passed default value on empty input
+func MinOr[T Number](def T, n ...T) T {
+	if len(n) == 0 {
+		return def
+	}
+	return Min(n...)
+}
+
+// isFloat checks whether type is a float, integer division would round the half down to 0
+func isFloat[T Number]() bool {
+	var one T = 1
+	return one/2 != 0
+}
+
+// numberLimits returns smallest and biggest value of integer type
+// for floats it returns -Inf and +Inf
+func numberLimits[T Number]() (min T, max T) {
+	if isFloat[T]() {
+		return T(math.Inf(-1)), T(math.Inf(1))
+	}
+	// keep setting bits till it wraps around; for signed types that stops before the sign bit
+	max = 1
+	for max*2+1 > max {
+		max = max*2 + 1
+	}
+	var zero T
+	if zero-1 < zero {
+		min = -max - 1
+	}
+	return min, max
+}
+
+func addChecked[T Number](a, b T) (sum T, overflow bool) {
+	sum = a + b
+	if isFloat[T]() {
+		return sum, math.IsInf(float64(sum), 0) && !math.IsInf(float64(a), 0) && !math.IsInf(float64(b), 0)
+	}
+	return sum, (b > 0 && sum < a) || (b < 0 && sum > a)
+}
+
+func mulChecked[T Number](a, b T) (product T, overflow bool) {
+	product = a * b
+	if isFloat[T]() {
+		return product, math.IsInf(float64(product), 0) && !math.IsInf(float64(a), 0) && !math.IsInf(float64(b), 0)
+	}
+	if a == 0 || b == 0 {
+		return product, false
+	}
+	// sign check catches MinInt * -1, which division check misses as MinInt / -1 == MinInt
+	return product, product/b != a || ((a < 0) != (b < 0)) != (product < 0)
+}
@@ -0,0 +1,141 @@
+package goneric
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestSumChecked(t *testing.T) {
+	s, err := SumChecked(1, 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, s)
+
+	_, err = SumChecked[int8](100, 27, 1)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	s8, err := SumChecked[int8](100, 27, -100, -100)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-73), s8)
+	_, err = SumChecked[int8](-100, -29)
+	assert.ErrorIs(t, err, ErrOverflow{})
+
+	_, err = SumChecked[uint8](200, 56)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	u8, err := SumChecked[uint8](200, 55)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), u8)
+
+	_, err = SumChecked[int64](math.MaxInt64, 1)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	_, err = SumChecked[uint64](math.MaxUint64, 1)
+	assert.ErrorIs(t, err, ErrOverflow{})
+
+	f, err := SumChecked(1.5, 2.5)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, f)
+	_, err = SumChecked(math.MaxFloat64, math.MaxFloat64)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	_, err = SumChecked[float32](math.MaxFloat32, math.MaxFloat32)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	// infinity on input is not an overflow
+	f, err = SumChecked(math.Inf(1), 1)
+	assert.NoError(t, err)
+	assert.True(t, math.IsInf(f, 1))
+}
+
+func TestSumSaturating(t *testing.T) {
+	assert.Equal(t, int8(127), SumSaturating[int8](100, 100))
+	assert.Equal(t, int8(-128), SumSaturating[int8](-100, -100))
+	assert.Equal(t, int8(27), SumSaturating[int8](100, 100, -100))
+	assert.Equal(t, int8(126), SumSaturating[int8](math.MaxInt8, 1, -1), "clamping happens after each addition")
+	assert.Equal(t, uint8(255), SumSaturating[uint8](200, 200))
+	assert.Equal(t, uint16(math.MaxUint16), SumSaturating[uint16](math.MaxUint16, 1))
+	assert.Equal(t, int64(math.MaxInt64), SumSaturating[int64](math.MaxInt64, 1))
+	assert.Equal(t, int32(math.MinInt32), SumSaturating[int32](math.MinInt32, -1))
+	assert.Equal(t, 6, SumSaturating(1, 2, 3))
+	assert.True(t, math.IsInf(SumSaturating(math.MaxFloat64, math.MaxFloat64), 1))
+}
+
+func TestMulChecked(t *testing.T) {
+	p, err := MulChecked(2, 3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 24, p)
+	p, err = MulChecked[int]()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, p)
+	p, err = MulChecked(5, 0, math.MaxInt)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, p)
+
+	_, err = MulChecked[int8](16, 8)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	i8, err := MulChecked[int8](-16, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), i8)
+	_, err = MulChecked[int8](-128, -1)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	_, err = MulChecked[int8](-1, -128)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	_, err = MulChecked[int64](math.MinInt64, -1)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	_, err = MulChecked[uint8](16, 16)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	u8, err := MulChecked[uint8](15, 17)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), u8)
+	_, err = MulChecked(1e200, 1e200)
+	assert.ErrorIs(t, err, ErrOverflow{})
+}
+
+func TestMulSaturating(t *testing.T) {
+	assert.Equal(t, int8(127), MulSaturating[int8](16, 8))
+	assert.Equal(t, int8(-128), MulSaturating[int8](-16, 9))
+	assert.Equal(t, int8(127), MulSaturating[int8](-128, -1))
+	assert.Equal(t, uint8(255), MulSaturating[uint8](16, 16))
+	assert.Equal(t, 24, MulSaturating(2, 3, 4))
+	assert.Equal(t, 1, MulSaturating[int]())
+}
+
+func TestAvgChecked(t *testing.T) {
+	a, err := AvgChecked(1, 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, a)
+	_, err = AvgChecked[uint8](200, 100)
+	assert.ErrorIs(t, err, ErrOverflow{})
+	_, err = AvgChecked[int]()
+	assert.ErrorIs(t, err, ErrEmpty{})
+}
+
+func TestMaxMinOr(t *testing.T) {
+	v, ok := MaxOk(1, 3, 2)
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	v, ok = MaxOk[int]()
+	assert.False(t, ok)
+	assert.Equal(t, 0, v)
+	v, ok = MinOk(1, 3, -2)
+	assert.True(t, ok)
+	assert.Equal(t, -2, v)
+	_, ok = MinOk[int]()
+	assert.False(t, ok)
+
+	assert.Equal(t, 3, MaxOr(-1, 1, 3, 2))
+	assert.Equal(t, -1, MaxOr[int](-1))
+	assert.Equal(t, 1, MinOr(-1, 1, 3, 2))
+	assert.Equal(t, 42.0, MinOr[float64](42))
+}
+
+func TestNumberLimits(t *testing.T) {
+	min8, max8 := numberLimits[int8]()
+	assert.Equal(t, int8(math.MinInt8), min8)
+	assert.Equal(t, int8(math.MaxInt8), max8)
+	minU, maxU := numberLimits[uint32]()
+	assert.Equal(t, uint32(0), minU)
+	assert.Equal(t, uint32(math.MaxUint32), maxU)
+	min, max := numberLimits[int]()
+	assert.Equal(t, math.MinInt, min)
+	assert.Equal(t, math.MaxInt, max)
+	minF, maxF := numberLimits[float32]()
+	assert.True(t, math.IsInf(float64(minF), -1))
+	assert.True(t, math.IsInf(float64(maxF), 1))
+}
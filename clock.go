@@ -0,0 +1,122 @@
+package goneric
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for time-dependent functions, so they can be tested without waiting.
+// RealClock uses time package, FakeClock is advanced manually
+type Clock interface {
+	// Now returns current time
+	Now() time.Time
+	// Sleep blocks for given duration
+	Sleep(d time.Duration)
+	// After returns channel that receives current time once given duration passes
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is Clock backed by time package
+type RealClock struct{}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (RealClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// FakeClock is Clock that only moves when Advance or Set is called, intended for tests.
+// Sleep and After wait until clock is advanced past their deadline.
+// It is safe for concurrent use
+type FakeClock struct {
+	lock    sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []fakeClockWaiter
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewFakeClock creates FakeClock set to given time
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.lock)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeClockWaiter{deadline: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance moves clock forward, waking up every Sleep and After whose deadline passed
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set sets clock to given time, waking up every Sleep and After whose deadline passed
+func (c *FakeClock) Set(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.set(now)
+}
+
+// Waiters returns number of Sleep and After calls waiting for clock to advance
+func (c *FakeClock) Waiters() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until at least n Sleep or After calls are waiting for clock to advance.
+// Use it to make sure code under test reached the wait before calling Advance
+func (c *FakeClock) BlockUntil(n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) set(now time.Time) {
+	c.now = now
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if now.Before(w.deadline) {
+			pending = append(pending, w)
+		} else {
+			w.ch <- now
+		}
+	}
+	clear(c.waiters[len(pending):])
+	c.waiters = pending
+}
@@ -0,0 +1,47 @@
+package goneric
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRealClock(t *testing.T) {
+	var c Clock = RealClock{}
+	start := c.Now()
+	c.Sleep(time.Millisecond)
+	<-c.After(time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*2)
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	assert.Equal(t, start, c.Now())
+
+	a1 := c.After(time.Second)
+	a2 := c.After(time.Second * 3)
+	assert.Equal(t, 2, c.Waiters())
+	select {
+	case <-a1:
+		t.Fatal("should not fire before advance")
+	default:
+	}
+	c.Advance(time.Second * 2)
+	assert.Equal(t, start.Add(time.Second*2), <-a1)
+	assert.Equal(t, 1, c.Waiters())
+	c.Set(start.Add(time.Minute))
+	assert.Equal(t, start.Add(time.Minute), <-a2)
+	assert.Equal(t, 0, c.Waiters())
+	// non-positive duration fires immediately
+	assert.Equal(t, start.Add(time.Minute), <-c.After(0))
+
+	done := make(chan time.Time)
+	go func() {
+		c.Sleep(time.Hour)
+		done <- c.Now()
+	}()
+	c.BlockUntil(1)
+	c.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Minute+time.Hour), <-done)
+}
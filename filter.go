@@ -1,5 +1,7 @@
 package goneric
 
+import "time"
+
 // FilterMap runs function on every element of map and adds it to result map if it returned true
 func FilterMap[K comparable, V any](filterFunc func(k K, v V) (accept bool), in map[K]V) (out map[K]V) {
 	out = make(map[K]V, 0)
@@ -57,3 +59,160 @@ func FilterChanErr[T any](filterFunc func(T) (bool, error), in chan T) (out chan
 	}()
 	return out, errCh
 }
+
+// ChanDebounce sends value from input channel only after no new value arrived for `d`,
+// so burst of values results in sending only the last one.
+// Value waiting to be sent is sent immediately when input is closed
+// close is propagated
+func ChanDebounce[T any](in chan T, d time.Duration) (out chan T) {
+	return ChanDebounceClock(RealClock{}, in, d)
+}
+
+// ChanDebounceClock is ChanDebounce using provided Clock for waiting
+func ChanDebounceClock[T any](clock Clock, in chan T, d time.Duration) (out chan T) {
+	out = make(chan T, 1)
+	go func() {
+		defer close(out)
+		var pending T
+		var deadline <-chan time.Time
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if deadline != nil {
+						out <- pending
+					}
+					return
+				}
+				pending = v
+				deadline = clock.After(d)
+			case <-deadline:
+				out <- pending
+				deadline = nil
+			}
+		}
+	}()
+	return out
+}
+
+// ChanThrottle sends at most one value per `d` interval, sending first value immediately
+// and discarding values that arrive before interval since last sent one passes
+// close is propagated
+func ChanThrottle[T any](in chan T, d time.Duration) (out chan T) {
+	return ChanThrottleClock(RealClock{}, in, d)
+}
+
+// ChanThrottleClock is ChanThrottle using provided Clock for time measurement
+func ChanThrottleClock[T any](clock Clock, in chan T, d time.Duration) (out chan T) {
+	out = make(chan T, 1)
+	go func() {
+		var last time.Time
+		sent := false
+		for v := range in {
+			if now := clock.Now(); !sent || now.Sub(last) >= d {
+				out <- v
+				last, sent = now, true
+			}
+		}
+		close(out)
+	}()
+	return out
+}
+
+// ChanThrottleTrailing sends at most one value per `d` interval: first value starts the interval,
+// and the latest value received during it is sent when it ends.
+// Value waiting to be sent is sent immediately when input is closed
+// close is propagated
+func ChanThrottleTrailing[T any](in chan T, d time.Duration) (out chan T) {
+	return ChanThrottleTrailingClock(RealClock{}, in, d)
+}
+
+// ChanThrottleTrailingClock is ChanThrottleTrailing using provided Clock for waiting
+func ChanThrottleTrailingClock[T any](clock Clock, in chan T, d time.Duration) (out chan T) {
+	out = make(chan T, 1)
+	go func() {
+		defer close(out)
+		var pending T
+		var deadline <-chan time.Time
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if deadline != nil {
+						out <- pending
+					}
+					return
+				}
+				if deadline == nil {
+					deadline = clock.After(d)
+				}
+				pending = v
+			case <-deadline:
+				out <- pending
+				deadline = nil
+			}
+		}
+	}()
+	return out
+}
+
+// ChanSample sends the latest value from input channel every `d`, skipping ticks when no new value arrived.
+// Value waiting to be sent is sent immediately when input is closed
+// close is propagated
+func ChanSample[T any](in chan T, d time.Duration) (out chan T) {
+	return ChanSampleClock(RealClock{}, in, d)
+}
+
+// ChanSampleClock is ChanSample using provided Clock for waiting
+func ChanSampleClock[T any](clock Clock, in chan T, d time.Duration) (out chan T) {
+	out = make(chan T, 1)
+	go func() {
+		defer close(out)
+		var pending T
+		hasPending := false
+		tick := clock.After(d)
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if hasPending {
+						out <- pending
+					}
+					return
+				}
+				pending, hasPending = v, true
+			case <-tick:
+				tick = clock.After(d)
+				if hasPending {
+					out <- pending
+					hasPending = false
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// ChanDistinctUntilChanged sends value only if it is different from the previous one
+// close is propagated
+func ChanDistinctUntilChanged[T comparable](in chan T) (out chan T) {
+	return ChanDistinctUntilChangedFunc(func(a, b T) bool { return a == b }, in)
+}
+
+// ChanDistinctUntilChangedFunc sends value only if `equalFunc` says it is different from the previous one
+// close is propagated
+func ChanDistinctUntilChangedFunc[T any](equalFunc func(a, b T) bool, in chan T) (out chan T) {
+	out = make(chan T, 1)
+	go func() {
+		var last T
+		first := true
+		for v := range in {
+			if first || !equalFunc(last, v) {
+				out <- v
+			}
+			last, first = v, false
+		}
+		close(out)
+	}()
+	return out
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"sync"
 	"testing"
+	"testing/synctest"
+	"time"
 )
 
 func TestFilterMap(t *testing.T) {
@@ -83,3 +85,95 @@ func TestFilterChanErr(t *testing.T) {
 	assert.Panics(t, func() { close(outCh) }, "make sure out channel is closed")
 	assert.Panics(t, func() { close(errCh) }, "make sure error channel is closed")
 }
+
+// fakeTimedRun feeds values to channel returned by `f`, each after given delay since the previous one,
+// advancing fake clock 1ms at a time, then closes input.
+// Returns received values with fake time since start they arrived at. Needs to run inside synctest bubble
+func fakeTimedRun[T any](f func(clock Clock, in chan T) chan T, values []T, delays []time.Duration) (out []string) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+	in := make(chan T)
+	res := f(clock, in)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := range res {
+			out = append(out, fmt.Sprintf("%v@%s", v, clock.Now().Sub(start)))
+		}
+	}()
+	for i, v := range values {
+		for elapsed := time.Duration(0); elapsed < delays[i]; elapsed += time.Millisecond {
+			synctest.Wait()
+			clock.Advance(time.Millisecond)
+		}
+		synctest.Wait()
+		in <- v
+	}
+	synctest.Wait()
+	close(in)
+	<-done
+	return out
+}
+
+func TestChanDebounce(t *testing.T) {
+	ms := time.Millisecond
+	synctest.Test(t, func(t *testing.T) {
+		out := fakeTimedRun(func(clock Clock, in chan int) chan int {
+			return ChanDebounceClock(clock, in, 50*ms)
+		}, []int{1, 2, 3, 4, 5}, []time.Duration{0, 10 * ms, 10 * ms, 100 * ms, 10 * ms})
+		assert.Equal(t, []string{"3@70ms", "5@130ms"}, out)
+	})
+	synctest.Test(t, func(t *testing.T) {
+		out := fakeTimedRun(func(clock Clock, in chan int) chan int {
+			return ChanDebounceClock(clock, in, time.Hour)
+		}, []int{1, 2}, []time.Duration{0, ms})
+		assert.Equal(t, []string{"2@1ms"}, out, "pending value should be sent on close")
+	})
+	in := make(chan int, 2)
+	in <- 1
+	in <- 2
+	close(in)
+	assert.Equal(t, []int{2}, ChanToSlice(ChanDebounce(in, time.Hour)))
+}
+
+func TestChanThrottle(t *testing.T) {
+	ms := time.Millisecond
+	synctest.Test(t, func(t *testing.T) {
+		out := fakeTimedRun(func(clock Clock, in chan int) chan int {
+			return ChanThrottleClock(clock, in, 50*ms)
+		}, []int{1, 2, 3, 4, 5}, []time.Duration{0, 10 * ms, 10 * ms, 40 * ms, 10 * ms})
+		assert.Equal(t, []string{"1@0s", "4@60ms"}, out)
+	})
+	assert.Equal(t, []int{1}, ChanToSlice(ChanThrottle(GenSliceToChan([]int{1, 2, 3}, true), time.Hour)))
+}
+
+func TestChanThrottleTrailing(t *testing.T) {
+	ms := time.Millisecond
+	synctest.Test(t, func(t *testing.T) {
+		out := fakeTimedRun(func(clock Clock, in chan int) chan int {
+			return ChanThrottleTrailingClock(clock, in, 50*ms)
+		}, []int{1, 2, 3, 4, 5}, []time.Duration{0, 10 * ms, 10 * ms, 40 * ms, 10 * ms})
+		// second interval starts at 60ms but input closes at 70ms, flushing pending value
+		assert.Equal(t, []string{"3@50ms", "5@70ms"}, out)
+	})
+	assert.Equal(t, []int{3}, ChanToSlice(ChanThrottleTrailing(GenSliceToChan([]int{1, 2, 3}, true), time.Hour)))
+}
+
+func TestChanSample(t *testing.T) {
+	ms := time.Millisecond
+	synctest.Test(t, func(t *testing.T) {
+		out := fakeTimedRun(func(clock Clock, in chan int) chan int {
+			return ChanSampleClock(clock, in, 100*ms)
+		}, []int{1, 2, 3, 4}, []time.Duration{10 * ms, 10 * ms, 200 * ms, 10 * ms})
+		assert.Equal(t, []string{"2@100ms", "4@230ms"}, out)
+	})
+	assert.Equal(t, []int{3}, ChanToSlice(ChanSample(GenSliceToChan([]int{1, 2, 3}, true), time.Hour)))
+}
+
+func TestChanDistinctUntilChanged(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 1, 3}, ChanToSlice(ChanDistinctUntilChanged(GenSliceToChan([]int{1, 1, 2, 2, 2, 1, 3, 3}, true))))
+	assert.Empty(t, ChanToSlice(ChanDistinctUntilChanged(GenSliceToChan([]int{}, true))))
+	out := ChanDistinctUntilChangedFunc(func(a, b []string) bool { return len(a) == len(b) },
+		GenSliceToChan([][]string{{"a"}, {"b"}, {"a", "b"}, {}}, true))
+	assert.Equal(t, [][]string{{"a"}, {"a", "b"}, {}}, ChanToSlice(out))
+}
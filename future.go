@@ -0,0 +1,217 @@
+package goneric
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Future holds result of asynchronous computation. Unlike channel returned by Async
+// it can be awaited any number of times, from any number of goroutines, and carries an error.
+// Panics in functions run by Future are recovered and returned as *PanicError
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// AsyncFuture runs function in goroutine and returns Future with its result
+func AsyncFuture[T any](f func() (T, error)) *Future[T] {
+	return runFuture(nil, f)
+}
+
+// AsyncFutureCtx runs function in goroutine passing it the context and returns Future with its result
+func AsyncFutureCtx[T any](ctx context.Context, f func(ctx context.Context) (T, error)) *Future[T] {
+	return runFuture(nil, func() (T, error) { return f(ctx) })
+}
+
+// AsyncVFuture runs a number of functions in goroutines and returns Future for each, in the same order.
+// *PanicError of recovered panic has function index as a Key
+func AsyncVFuture[T any](funcList ...func() (T, error)) []*Future[T] {
+	out := make([]*Future[T], len(funcList))
+	for i, f := range funcList {
+		out[i] = runFuture(i, f)
+	}
+	return out
+}
+
+// FutureOf returns already completed Future with given result
+func FutureOf[T any](value T, err error) *Future[T] {
+	fut := &Future[T]{done: make(chan struct{}), value: value, err: err}
+	close(fut.done)
+	return fut
+}
+
+// FutureThen returns Future that runs function on the result of `f` once it succeeds.
+// Error of `f` is passed thru without running the function. Use Then method if result type doesn't change
+func FutureThen[T1, T2 any](f *Future[T1], thenFunc func(T1) (T2, error)) *Future[T2] {
+	return runFuture(nil, func() (out T2, err error) {
+		v, err := f.Await()
+		if err != nil {
+			return out, err
+		}
+		return thenFunc(v)
+	})
+}
+
+// Then returns Future that runs function on the result once it succeeds, error is passed thru without running it.
+// See FutureThen for function changing the result type
+func (f *Future[T]) Then(thenFunc func(T) (T, error)) *Future[T] {
+	return FutureThen(f, thenFunc)
+}
+
+// Catch returns Future that runs function on the error if there was one, allowing to recover from it.
+// Successful result is passed thru without running it
+func (f *Future[T]) Catch(catchFunc func(error) (T, error)) *Future[T] {
+	return runFuture(nil, func() (T, error) {
+		v, err := f.Await()
+		if err == nil {
+			return v, nil
+		}
+		return catchFunc(err)
+	})
+}
+
+// Await blocks until result is available and returns it
+func (f *Future[T]) Await() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// AwaitCtx blocks until result is available or context is cancelled, returning ctx.Err() in the latter case.
+// Already available result is returned even if context is cancelled. Cancelling does not stop the computation
+func (f *Future[T]) AwaitCtx(ctx context.Context) (out T, err error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+	}
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		return out, ctx.Err()
+	}
+}
+
+// AwaitTimeout blocks until result is available or timeout passes, returning context.DeadlineExceeded in the latter case
+func (f *Future[T]) AwaitTimeout(timeout time.Duration) (out T, err error) {
+	return f.AwaitTimeoutClock(RealClock{}, timeout)
+}
+
+// AwaitTimeoutClock is AwaitTimeout using provided Clock for waiting
+func (f *Future[T]) AwaitTimeoutClock(clock Clock, timeout time.Duration) (out T, err error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-clock.After(timeout):
+		return out, context.DeadlineExceeded
+	}
+}
+
+// Done returns channel that is closed when result is available
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// AwaitAll waits for every Future and returns their results in the same order,
+// with errors joined via errors.Join. Failed ones have zero value in the result
+func AwaitAll[T any](futures ...*Future[T]) ([]T, error) {
+	return AwaitAllCtx(context.Background(), futures...)
+}
+
+// AwaitAllCtx works like AwaitAll but stops waiting when context is cancelled,
+// returning results of futures that finished by then and ctx.Err() joined with their errors
+func AwaitAllCtx[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	out := make([]T, len(futures))
+	var errs []error
+	var ctxErr error
+	for i, f := range futures {
+		if ctxErr == nil {
+			select {
+			case <-f.done:
+			default:
+				select {
+				case <-f.done:
+				case <-ctx.Done():
+					ctxErr = ctx.Err()
+				}
+			}
+		}
+		// after cancellation only collect futures that already finished
+		select {
+		case <-f.done:
+		default:
+			continue
+		}
+		if f.err != nil {
+			errs = append(errs, f.err)
+			continue
+		}
+		out[i] = f.value
+	}
+	if ctxErr != nil {
+		errs = append(errs, ctxErr)
+	}
+	return out, errors.Join(errs...)
+}
+
+// AwaitAny returns result of first Future that succeeds. If all of them fail, their errors are returned joined via errors.Join.
+// Returns ErrEmpty if there are no futures
+func AwaitAny[T any](futures ...*Future[T]) (T, error) {
+	return AwaitAnyCtx(context.Background(), futures...)
+}
+
+// AwaitAnyCtx works like AwaitAny but stops waiting when context is cancelled, returning ctx.Err() joined with errors so far
+func AwaitAnyCtx[T any](ctx context.Context, futures ...*Future[T]) (out T, err error) {
+	if len(futures) == 0 {
+		return out, ErrEmpty{}
+	}
+	finished := make(chan *Future[T], len(futures))
+	stop := make(chan struct{})
+	defer close(stop)
+	for _, f := range futures {
+		go func() {
+			select {
+			case <-f.done:
+				finished <- f
+			case <-stop:
+			}
+		}()
+	}
+	var errs []error
+	for range futures {
+		select {
+		case f := <-finished:
+			if f.err == nil {
+				return f.value, nil
+			}
+			errs = append(errs, f.err)
+		case <-ctx.Done():
+			return out, errors.Join(append(errs, ctx.Err())...)
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// runFuture runs function in goroutine, turning panic into *PanicError with given key
+func runFuture[T any](key any, f func() (T, error)) *Future[T] {
+	fut := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(fut.done)
+		type result struct {
+			v   T
+			err error
+		}
+		res, err := unpanic(key, func(struct{}) result {
+			v, err := f()
+			return result{v: v, err: err}
+		}, struct{}{})
+		if err != nil {
+			fut.err = err
+			return
+		}
+		fut.value, fut.err = res.v, res.err
+	}()
+	return fut
+}
@@ -0,0 +1,209 @@
+package goneric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestFuture(t *testing.T) {
+	f := AsyncFuture(func() (int, error) { return 42, nil })
+	v, err := f.Await()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+	v, err = f.Await()
+	assert.Equal(t, 42, v, "awaiting twice should return same result")
+	<-f.Done()
+
+	errFail := errors.New("fail")
+	_, err = AsyncFuture(func() (int, error) { return 0, errFail }).Await()
+	assert.ErrorIs(t, err, errFail)
+
+	_, err = AsyncFuture(func() (int, error) { panic("boom") }).Await()
+	var perr *PanicError
+	assert.ErrorAs(t, err, &perr)
+	assert.Equal(t, "boom", perr.Value)
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	s, err := AsyncFutureCtx(ctx, func(ctx context.Context) (string, error) {
+		return ctx.Value(ctxKey{}).(string), nil
+	}).Await()
+	assert.NoError(t, err)
+	assert.Equal(t, "value", s)
+}
+
+func TestAsyncVFuture(t *testing.T) {
+	futures := AsyncVFuture(
+		func() (int, error) { return 1, nil },
+		func() (int, error) { panic("second") },
+		func() (int, error) { return 3, nil },
+	)
+	assert.Len(t, futures, 3)
+	v, err := futures[2].Await()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	_, err = futures[1].Await()
+	var perr *PanicError
+	assert.ErrorAs(t, err, &perr)
+	assert.Equal(t, 1, perr.Key)
+}
+
+func TestFutureThenCatch(t *testing.T) {
+	s, err := FutureThen(FutureOf(21, nil), func(v int) (string, error) {
+		return strconv.Itoa(v * 2), nil
+	}).Await()
+	assert.NoError(t, err)
+	assert.Equal(t, "42", s)
+
+	errFail := errors.New("fail")
+	called := false
+	_, err = FutureOf(1, errFail).Then(func(v int) (int, error) {
+		called = true
+		return v, nil
+	}).Await()
+	assert.ErrorIs(t, err, errFail)
+	assert.False(t, called, "then should not run on error")
+
+	v, err := FutureOf(1, errFail).Catch(func(err error) (int, error) {
+		return -1, nil
+	}).Then(func(v int) (int, error) { return v * 10, nil }).Await()
+	assert.NoError(t, err)
+	assert.Equal(t, -10, v)
+
+	v, err = FutureOf(5, nil).Catch(func(err error) (int, error) { return -1, nil }).Await()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, v, "catch should not run on success")
+
+	_, err = FutureOf(5, nil).Then(func(v int) (int, error) { panic("in then") }).Await()
+	assert.ErrorContains(t, err, "in then")
+}
+
+func TestFutureAwaitTimeout(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		f := AsyncFuture(func() (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil
+		})
+		_, err := f.AwaitTimeout(time.Millisecond * 100)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+		_, err = f.AwaitCtx(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		v, err := f.AwaitTimeout(time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+		v, err = f.AwaitCtx(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+	})
+}
+
+func TestFutureAwaitTimeoutClock(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+		release := make(chan struct{})
+		f := AsyncFuture(func() (int, error) {
+			<-release
+			return 1, nil
+		})
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := f.AwaitTimeoutClock(clock, time.Second)
+			errCh <- err
+		}()
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		assert.ErrorIs(t, <-errCh, context.DeadlineExceeded)
+		close(release)
+		v, err := f.AwaitTimeoutClock(clock, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+	})
+}
+
+func TestAwaitAll(t *testing.T) {
+	errFail := errors.New("fail")
+	out, err := AwaitAll(FutureOf(1, nil), FutureOf(2, errFail), FutureOf(3, nil))
+	assert.ErrorIs(t, err, errFail)
+	assert.Equal(t, []int{1, 0, 3}, out)
+	out, err = AwaitAll[int]()
+	assert.NoError(t, err)
+	assert.Empty(t, out)
+
+	synctest.Test(t, func(t *testing.T) {
+		slow := AsyncFuture(func() (int, error) {
+			time.Sleep(time.Hour)
+			return 2, nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		out, err := AwaitAllCtx(ctx, FutureOf(1, nil), slow)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, []int{1, 0}, out)
+		out, err = AwaitAllCtx(ctx, slow, FutureOf(3, nil))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, []int{0, 3}, out, "futures finished before cancellation should be collected")
+		slow.Await()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	out, err = AwaitAllCtx(ctx, FutureOf(1, nil), FutureOf(2, errFail))
+	assert.Equal(t, []int{1, 0}, out, "completed futures should be returned even if context is cancelled")
+	assert.ErrorIs(t, err, errFail)
+	assert.NotErrorIs(t, err, context.Canceled)
+
+	// future failing with context.Canceled is not the await being cancelled
+	out, err = AwaitAllCtx(ctx, FutureOf(0, context.Canceled), FutureOf(5, nil))
+	assert.Equal(t, []int{0, 5}, out)
+	assert.ErrorIs(t, err, context.Canceled)
+	out, err = AwaitAll(FutureOf(0, context.Canceled), FutureOf(5, nil))
+	assert.Equal(t, []int{0, 5}, out)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAwaitAny(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		errFail := errors.New("fail")
+		delayed := func(d time.Duration, v int, err error) *Future[int] {
+			return AsyncFuture(func() (int, error) {
+				time.Sleep(d)
+				return v, err
+			})
+		}
+		start := time.Now()
+		v, err := AwaitAny(delayed(time.Second*3, 3, nil), delayed(time.Second, 1, errFail), delayed(time.Second*2, 2, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, v, "first success should win over earlier failure")
+		assert.Equal(t, time.Second*2, time.Since(start))
+
+		_, err = AwaitAny(FutureOf(0, errFail), FutureOf(0, errors.New("other")))
+		assert.ErrorIs(t, err, errFail)
+		assert.ErrorContains(t, err, "other")
+		_, err = AwaitAny[int]()
+		assert.ErrorIs(t, err, ErrEmpty{})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		slow := delayed(time.Hour, 1, nil)
+		_, err = AwaitAnyCtx(ctx, slow)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		slow.Await()
+	})
+}
+
+func ExampleFutureThen() {
+	price := AsyncFuture(func() (float64, error) { return 9.99, nil })
+	label := FutureThen(price, func(p float64) (string, error) {
+		return fmt.Sprintf("$%.2f", p), nil
+	})
+	fmt.Println(label.Await())
+	// Output: $9.99 <nil>
+}
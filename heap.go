@@ -0,0 +1,161 @@
+package goneric
+
+import "iter"
+
+// Heap is a binary heap ordered by `less` function, Pop returns the smallest element first.
+// It is not safe for concurrent use
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewHeap creates Heap ordered by `less` from passed elements
+func NewHeap[T any](less func(a, b T) bool, items ...T) *Heap[T] {
+	h := &Heap[T]{
+		items: make([]T, len(items)),
+		less:  less,
+	}
+	copy(h.items, items)
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Push adds elements to the heap
+func (h *Heap[T]) Push(v ...T) {
+	for _, e := range v {
+		h.items = append(h.items, e)
+		h.up(len(h.items) - 1)
+	}
+}
+
+// Pop removes and returns smallest element, false if heap is empty
+func (h *Heap[T]) Pop() (v T, ok bool) {
+	if len(h.items) == 0 {
+		return v, false
+	}
+	v = h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	var zero T
+	// clear the slot so popped element can be garbage collected
+	h.items[last] = zero
+	h.items = h.items[:last]
+	if last > 0 {
+		h.down(0)
+	}
+	return v, true
+}
+
+// Peek returns smallest element without removing it, false if heap is empty
+func (h *Heap[T]) Peek() (v T, ok bool) {
+	if len(h.items) == 0 {
+		return v, false
+	}
+	return h.items[0], true
+}
+
+// Len returns number of elements
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Drain returns iterator that pops elements in order until heap is empty
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(h.items) > 0 {
+			v, _ := h.Pop()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	n := len(h.items)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && h.less(h.items[l], h.items[smallest]) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && h.less(h.items[r], h.items[smallest]) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		h.items[i], h.items[smallest] = h.items[smallest], h.items[i]
+		i = smallest
+	}
+}
+
+// PriorityQueue returns elements with highest priority first, elements with equal priority are returned in order they were added.
+// Zero value is ready to use. It is not safe for concurrent use
+type PriorityQueue[T any] struct {
+	heap *Heap[priorityQueueItem[T]]
+	seq  uint64
+}
+
+type priorityQueueItem[T any] struct {
+	v        T
+	priority int
+	seq      uint64
+}
+
+// NewPriorityQueue creates empty PriorityQueue
+func NewPriorityQueue[T any]() *PriorityQueue[T] {
+	q := &PriorityQueue[T]{}
+	q.init()
+	return q
+}
+
+// Push adds element with given priority
+func (q *PriorityQueue[T]) Push(v T, priority int) {
+	q.init()
+	q.heap.Push(priorityQueueItem[T]{v: v, priority: priority, seq: q.seq})
+	q.seq++
+}
+
+// Pop removes and returns element with highest priority, false if queue is empty
+func (q *PriorityQueue[T]) Pop() (v T, ok bool) {
+	q.init()
+	item, ok := q.heap.Pop()
+	return item.v, ok
+}
+
+// Peek returns element with highest priority without removing it, false if queue is empty
+func (q *PriorityQueue[T]) Peek() (v T, ok bool) {
+	q.init()
+	item, ok := q.heap.Peek()
+	return item.v, ok
+}
+
+// Len returns number of elements
+func (q *PriorityQueue[T]) Len() int {
+	q.init()
+	return q.heap.Len()
+}
+
+func (q *PriorityQueue[T]) init() {
+	if q.heap == nil {
+		q.heap = NewHeap(func(a, b priorityQueueItem[T]) bool {
+			if a.priority != b.priority {
+				return a.priority > b.priority
+			}
+			return a.seq < b.seq
+		})
+	}
+}
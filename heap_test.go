@@ -0,0 +1,72 @@
+package goneric
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestHeap(t *testing.T) {
+	data := GenSlice(100, func(idx int) int { return rand.Intn(50) })
+	h := NewHeap(func(a, b int) bool { return a < b }, data[:50]...)
+	h.Push(data[50:]...)
+	assert.Equal(t, 100, h.Len())
+	min, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, slices.Min(data), min)
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+	assert.Equal(t, sorted, slices.Collect(h.Drain()))
+	assert.Equal(t, 0, h.Len())
+	_, ok = h.Pop()
+	assert.False(t, ok)
+	_, ok = h.Peek()
+	assert.False(t, ok)
+
+	// max-heap via reversed less
+	maxHeap := NewHeap(func(a, b string) bool { return a > b }, "b", "c", "a")
+	v, _ := maxHeap.Pop()
+	assert.Equal(t, "c", v)
+	for range maxHeap.Drain() {
+		break
+	}
+	assert.Equal(t, 1, maxHeap.Len(), "stopping iteration should leave rest in heap")
+}
+
+func TestPriorityQueue(t *testing.T) {
+	q := PriorityQueue[string]{}
+	assert.Equal(t, 0, q.Len())
+	_, ok := q.Pop()
+	assert.False(t, ok)
+	q.Push("low1", 1)
+	q.Push("high", 10)
+	q.Push("low2", 1)
+	q.Push("mid", 5)
+	q.Push("low3", 1)
+	v, ok := q.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "high", v)
+	var out []string
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		out = append(out, v)
+	}
+	assert.Equal(t, []string{"high", "mid", "low1", "low2", "low3"}, out)
+	assert.Equal(t, 0, NewPriorityQueue[int]().Len())
+}
+
+func ExamplePriorityQueue() {
+	q := NewPriorityQueue[string]()
+	q.Push("backfill", 0)
+	q.Push("user request", 10)
+	q.Push("report", 5)
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		fmt.Println(v)
+	}
+	// Output: user request
+	// report
+	// backfill
+}
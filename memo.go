@@ -0,0 +1,224 @@
+package goneric
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// SingleFlight collapses concurrent calls with the same key into one execution, all callers get its result.
+// Panics are recovered and returned to every caller as *PanicError with the key as a Key.
+// Zero value is ready to use, it is safe for concurrent use
+type SingleFlight[K comparable, V any] struct {
+	lock  sync.Mutex
+	calls map[K]*singleFlightCall[V]
+}
+
+type singleFlightCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Do runs function unless call with the same key is already running, in which case it waits for its result instead.
+// `shared` is true if result came from call started by another caller
+func (s *SingleFlight[K, V]) Do(key K, f func() (V, error)) (value V, err error, shared bool) {
+	s.lock.Lock()
+	if s.calls == nil {
+		s.calls = make(map[K]*singleFlightCall[V])
+	}
+	if c, ok := s.calls[key]; ok {
+		s.lock.Unlock()
+		<-c.done
+		return c.value, c.err, true
+	}
+	c := &singleFlightCall[V]{done: make(chan struct{})}
+	s.calls[key] = c
+	s.lock.Unlock()
+
+	c.value, c.err = singleFlightRun(key, f)
+	s.lock.Lock()
+	// Forget might have let another call take the slot in the meantime
+	if s.calls[key] == c {
+		delete(s.calls, key)
+	}
+	s.lock.Unlock()
+	close(c.done)
+	return c.value, c.err, false
+}
+
+// Forget makes next Do with the key start new call instead of waiting for the running one
+func (s *SingleFlight[K, V]) Forget(key K) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.calls, key)
+}
+
+func singleFlightRun[K comparable, V any](key K, f func() (V, error)) (V, error) {
+	type result struct {
+		value V
+		err   error
+	}
+	res, err := unpanic(key, func(struct{}) result {
+		v, err := f()
+		return result{value: v, err: err}
+	}, struct{}{})
+	if err != nil {
+		return res.value, err
+	}
+	return res.value, res.err
+}
+
+// MemoOptions configures Memo
+type MemoOptions struct {
+	// TTL is how long results are kept, 0 means forever
+	TTL time.Duration
+	// MaxSize limits number of cached results, evicting least recently used ones. 0 means no limit
+	MaxSize int
+	// CacheErrors makes failed calls cached too, by default only successful results are
+	CacheErrors bool
+	// ErrorTTL is how long failed results are kept if CacheErrors is set, 0 means same as TTL
+	ErrorTTL time.Duration
+	// Clock used for expiring results, nil means RealClock
+	Clock Clock
+}
+
+// Memo caches results of function by its argument. Concurrent calls with the same argument
+// that miss the cache are collapsed into one call via SingleFlight.
+// It is safe for concurrent use
+type Memo[K comparable, V any] struct {
+	f      func(K) (V, error)
+	opts   MemoOptions
+	flight SingleFlight[K, V]
+	lock   sync.Mutex
+	items  map[K]*list.Element
+	// most recently used first
+	lru *list.List
+}
+
+type memoEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	err     error
+	expires time.Time
+}
+
+// NewMemo creates Memo caching results of the function
+func NewMemo[K comparable, V any](f func(K) (V, error), opts MemoOptions) *Memo[K, V] {
+	if opts.Clock == nil {
+		opts.Clock = RealClock{}
+	}
+	return &Memo[K, V]{
+		f:     f,
+		opts:  opts,
+		items: make(map[K]*list.Element),
+		lru:   list.New(),
+	}
+}
+
+// Get returns cached result for the argument, calling the function if there is none
+func (m *Memo[K, V]) Get(key K) (V, error) {
+	if e, ok := m.get(key); ok {
+		return e.value, e.err
+	}
+	value, err, _ := m.flight.Do(key, func() (V, error) { return m.f(key) })
+	if err == nil || m.opts.CacheErrors {
+		m.set(key, value, err)
+	}
+	return value, err
+}
+
+// Forget removes cached result for the argument
+func (m *Memo[K, V]) Forget(key K) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+}
+
+// Len returns number of cached results, including expired ones that were not removed yet
+func (m *Memo[K, V]) Len() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.items)
+}
+
+func (m *Memo[K, V]) get(key K) (e *memoEntry[K, V], ok bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	e = el.Value.(*memoEntry[K, V])
+	if !e.expires.IsZero() && !m.opts.Clock.Now().Before(e.expires) {
+		m.remove(el)
+		return nil, false
+	}
+	m.lru.MoveToFront(el)
+	return e, true
+}
+
+func (m *Memo[K, V]) set(key K, value V, err error) {
+	e := &memoEntry[K, V]{key: key, value: value, err: err}
+	ttl := m.opts.TTL
+	if err != nil && m.opts.ErrorTTL > 0 {
+		ttl = m.opts.ErrorTTL
+	}
+	if ttl > 0 {
+		e.expires = m.opts.Clock.Now().Add(ttl)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if el, ok := m.items[key]; ok {
+		el.Value = e
+		m.lru.MoveToFront(el)
+		return
+	}
+	m.items[key] = m.lru.PushFront(e)
+	if m.opts.MaxSize > 0 && m.lru.Len() > m.opts.MaxSize {
+		m.remove(m.lru.Back())
+	}
+}
+
+func (m *Memo[K, V]) remove(el *list.Element) {
+	m.lru.Remove(el)
+	delete(m.items, el.Value.(*memoEntry[K, V]).key)
+}
+
+// Memoize returns function caching results of passed one by its argument, forever.
+// Concurrent calls with the same argument are collapsed into one call.
+// Panic in the function is re-raised as *PanicError in every caller that waited for it
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	return memoizeOpts(f, MemoOptions{})
+}
+
+// MemoizeTTL works like Memoize but results expire after `ttl`
+func MemoizeTTL[K comparable, V any](f func(K) V, ttl time.Duration) func(K) V {
+	return memoizeOpts(f, MemoOptions{TTL: ttl})
+}
+
+// MemoizeLRU works like Memoize but keeps at most `size` results, evicting least recently used ones
+func MemoizeLRU[K comparable, V any](f func(K) V, size int) func(K) V {
+	return memoizeOpts(f, MemoOptions{MaxSize: size})
+}
+
+// MemoizeErr returns function caching successful results of passed one by its argument, forever.
+// Errors are not cached, use NewMemo with MemoOptions for error caching, TTL and size limit.
+// Concurrent calls with the same argument are collapsed into one call, panics are returned as *PanicError
+func MemoizeErr[K comparable, V any](f func(K) (V, error)) func(K) (V, error) {
+	return NewMemo(f, MemoOptions{}).Get
+}
+
+func memoizeOpts[K comparable, V any](f func(K) V, opts MemoOptions) func(K) V {
+	m := NewMemo(func(k K) (V, error) { return f(k), nil }, opts)
+	return func(k K) V {
+		v, err := m.Get(k)
+		if err != nil {
+			// only possible error is recovered panic
+			panic(err)
+		}
+		return v
+	}
+}
@@ -0,0 +1,163 @@
+package goneric
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestSingleFlight(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		sf := SingleFlight[string, int]{}
+		var calls atomic.Int64
+		var sharedCount atomic.Int64
+		wg := sync.WaitGroup{}
+		for range 10 {
+			wg.Go(func() {
+				v, err, shared := sf.Do("key", func() (int, error) {
+					calls.Add(1)
+					time.Sleep(time.Second)
+					return 42, nil
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, 42, v)
+				if shared {
+					sharedCount.Add(1)
+				}
+			})
+		}
+		wg.Wait()
+		assert.Equal(t, int64(1), calls.Load())
+		assert.Equal(t, int64(9), sharedCount.Load())
+
+		// finished call is not reused
+		v, _, shared := sf.Do("key", func() (int, error) { return 7, nil })
+		assert.Equal(t, 7, v)
+		assert.False(t, shared)
+
+		_, err, _ := sf.Do("panic", func() (int, error) { panic("boom") })
+		var perr *PanicError
+		assert.ErrorAs(t, err, &perr)
+		assert.Equal(t, "panic", perr.Key)
+	})
+}
+
+func TestSingleFlightForget(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		sf := SingleFlight[int, string]{}
+		release := make(chan bool)
+		go sf.Do(1, func() (string, error) { <-release; return "old", nil })
+		synctest.Wait()
+		sf.Forget(1)
+		v, _, shared := sf.Do(1, func() (string, error) { return "new", nil })
+		assert.Equal(t, "new", v)
+		assert.False(t, shared)
+		close(release)
+	})
+}
+
+func TestMemoize(t *testing.T) {
+	calls := map[int]int{}
+	lock := sync.Mutex{}
+	f := Memoize(func(v int) string {
+		lock.Lock()
+		calls[v]++
+		lock.Unlock()
+		return strconv.Itoa(v)
+	})
+	for range 3 {
+		assert.Equal(t, "1", f(1))
+		assert.Equal(t, "2", f(2))
+	}
+	assert.Equal(t, map[int]int{1: 1, 2: 1}, calls)
+
+	// usable as worker
+	async, stop := WorkerPoolAsync(f, 2)
+	assert.Equal(t, "3", <-async(3))
+	assert.Equal(t, "1", <-async(1))
+	stop()
+	assert.Equal(t, map[int]int{1: 1, 2: 1, 3: 1}, calls)
+
+	p := Memoize(func(v int) int { panic("boom") })
+	assert.PanicsWithError(t, "panic on input [1]: boom", func() { p(1) })
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		calls := 0
+		f := MemoizeTTL(func(v int) int { calls++; return v * 2 }, time.Minute)
+		assert.Equal(t, 2, f(1))
+		time.Sleep(time.Second * 59)
+		assert.Equal(t, 2, f(1))
+		assert.Equal(t, 1, calls)
+		time.Sleep(time.Second)
+		assert.Equal(t, 2, f(1))
+		assert.Equal(t, 2, calls, "expired result should be recalculated")
+	})
+}
+
+func TestMemoizeLRU(t *testing.T) {
+	var calls []int
+	f := MemoizeLRU(func(v int) int { calls = append(calls, v); return v }, 2)
+	f(1)
+	f(2)
+	f(1) // 1 is now most recently used
+	f(3) // evicts 2
+	f(1)
+	f(2)
+	assert.Equal(t, []int{1, 2, 3, 2}, calls)
+}
+
+func TestMemoizeErr(t *testing.T) {
+	calls := 0
+	errFail := errors.New("fail")
+	f := MemoizeErr(func(v int) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errFail
+		}
+		return v, nil
+	})
+	_, err := f(5)
+	assert.ErrorIs(t, err, errFail)
+	v, err := f(5)
+	assert.NoError(t, err, "errors should not be cached")
+	assert.Equal(t, 5, v)
+	f(5)
+	assert.Equal(t, 2, calls)
+}
+
+func TestMemo(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	errFail := errors.New("fail")
+	calls := 0
+	m := NewMemo(func(v string) (int, error) {
+		calls++
+		if v == "bad" {
+			return 0, errFail
+		}
+		return len(v), nil
+	}, MemoOptions{TTL: time.Hour, CacheErrors: true, ErrorTTL: time.Minute, Clock: clock})
+	_, err := m.Get("bad")
+	assert.ErrorIs(t, err, errFail)
+	_, err = m.Get("bad")
+	assert.ErrorIs(t, err, errFail)
+	assert.Equal(t, 1, calls, "error should be cached")
+	v, _ := m.Get("good")
+	assert.Equal(t, 4, v)
+	assert.Equal(t, 2, m.Len())
+
+	clock.Advance(time.Minute)
+	m.Get("bad")
+	m.Get("good")
+	assert.Equal(t, 3, calls, "error should expire after ErrorTTL, value after TTL")
+
+	m.Forget("good")
+	m.Get("good")
+	assert.Equal(t, 4, calls)
+}
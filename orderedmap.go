@@ -0,0 +1,251 @@
+package goneric
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"sort"
+)
+
+// OrderedMap is a map that keeps insertion order of its keys.
+// Setting existing key updates the value but keeps its position.
+// Zero value is ready to use. It is not safe for concurrent use.
+// Marshals to/from JSON object with keys in order, key types follow the same rules as `encoding/json` uses for maps
+type OrderedMap[K comparable, V any] struct {
+	items map[K]*orderedMapEntry[K, V]
+	// sentinel of circular list, root.next is first element
+	root *orderedMapEntry[K, V]
+}
+
+type orderedMapEntry[K comparable, V any] struct {
+	KeyValue[K, V]
+	prev, next *orderedMapEntry[K, V]
+}
+
+// NewOrderedMap creates empty OrderedMap
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{}
+	m.init()
+	return m
+}
+
+// OrderedMapFromMap creates OrderedMap from map, with keys inserted in order sorted via `sortFuncLess(left K, right K)`
+func OrderedMapFromMap[K comparable, V any](in map[K]V, sortFuncLess func(left K, right K) bool) *OrderedMap[K, V] {
+	m := NewOrderedMap[K, V]()
+	keys := MapSliceKey(in)
+	sort.Slice(keys, func(i int, j int) bool {
+		return sortFuncLess(keys[i], keys[j])
+	})
+	for _, k := range keys {
+		m.Set(k, in[k])
+	}
+	return m
+}
+
+func (m *OrderedMap[K, V]) init() {
+	if m.items == nil {
+		m.items = make(map[K]*orderedMapEntry[K, V])
+		m.root = &orderedMapEntry[K, V]{}
+		m.root.next = m.root
+		m.root.prev = m.root
+	}
+}
+
+// Set sets the value of the key. New keys are added at the end, existing ones keep their position
+func (m *OrderedMap[K, V]) Set(k K, v V) {
+	m.init()
+	if e, ok := m.items[k]; ok {
+		e.V = v
+		return
+	}
+	e := &orderedMapEntry[K, V]{
+		KeyValue: KeyValue[K, V]{K: k, V: v},
+		prev:     m.root.prev,
+		next:     m.root,
+	}
+	m.root.prev.next = e
+	m.root.prev = e
+	m.items[k] = e
+}
+
+// Get returns value of the key and whether it was present
+func (m *OrderedMap[K, V]) Get(k K) (v V, ok bool) {
+	e, ok := m.items[k]
+	if !ok {
+		return v, false
+	}
+	return e.V, true
+}
+
+// Has checks whether key is present
+func (m *OrderedMap[K, V]) Has(k K) bool {
+	_, ok := m.items[k]
+	return ok
+}
+
+// Delete removes the key, does nothing if it is not present
+func (m *OrderedMap[K, V]) Delete(k K) {
+	e, ok := m.items[k]
+	if !ok {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	// nil prev marks entry as deleted, next is kept so iteration that holds it can get back to the list
+	e.prev = nil
+	delete(m.items, k)
+}
+
+// Len returns number of elements
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.items)
+}
+
+// All returns iterator over key/value pairs in insertion order
+// Deleting elements during iteration is safe, deleted ones that weren't reached yet are skipped
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.items == nil {
+			return
+		}
+		for e := m.root.next; e != m.root; e = e.next {
+			if e.prev == nil {
+				continue
+			}
+			if !yield(e.K, e.V) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns keys in insertion order
+func (m *OrderedMap[K, V]) Keys() (out []K) {
+	out = make([]K, 0, m.Len())
+	for k := range m.All() {
+		out = append(out, k)
+	}
+	return out
+}
+
+// Values returns values in insertion order
+func (m *OrderedMap[K, V]) Values() (out []V) {
+	out = make([]V, 0, m.Len())
+	for _, v := range m.All() {
+		out = append(out, v)
+	}
+	return out
+}
+
+// Map returns copy of elements as plain map, to be used with other map functions
+func (m *OrderedMap[K, V]) Map() map[K]V {
+	out := make(map[K]V, m.Len())
+	for k, v := range m.All() {
+		out[k] = v
+	}
+	return out
+}
+
+// MarshalJSON encodes map as JSON object with keys in insertion order.
+// It has value receiver so OrderedMap fields marshal correctly also when containing struct is passed by value
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	first := true
+	for k, v := range m.All() {
+		// encode single element map so key encoding rules are exactly the same as in encoding/json
+		element, err := json.Marshal(map[K]V{k: v})
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(element[1 : len(element)-1])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes JSON object into map, replacing its content and keeping the order of keys from the input
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		// null is a no-op, same as for regular maps
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("cannot unmarshal %v into OrderedMap, expected object", tok)
+	}
+	*m = OrderedMap[K, V]{}
+	m.init()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := json.Marshal(tok.(string))
+		if err != nil {
+			return err
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		// decode single element map so key decoding rules are exactly the same as in encoding/json
+		element := map[K]V{}
+		if err := json.Unmarshal(bytes.Join([][]byte{[]byte("{"), key, []byte(":"), raw, []byte("}")}, nil), &element); err != nil {
+			return err
+		}
+		for k, v := range element {
+			m.Set(k, v)
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// MapOrderedMap runs every element thru function that returns new key and value, and returns that in another OrderedMap, keeping the order.
+// Types can vary between in and out. If function returns the same key twice, the later value wins and the first position is kept
+func MapOrderedMap[K1, K2 comparable, V1, V2 any](mapFunc func(k K1, v V1) (K2, V2), in *OrderedMap[K1, V1]) *OrderedMap[K2, V2] {
+	out := NewOrderedMap[K2, V2]()
+	for k, v := range in.All() {
+		out.Set(mapFunc(k, v))
+	}
+	return out
+}
+
+// FilterOrderedMap runs function on every element of OrderedMap and adds it to result if it returned true, keeping the order
+func FilterOrderedMap[K comparable, V any](filterFunc func(k K, v V) (accept bool), in *OrderedMap[K, V]) *OrderedMap[K, V] {
+	out := NewOrderedMap[K, V]()
+	for k, v := range in.All() {
+		if filterFunc(k, v) {
+			out.Set(k, v)
+		}
+	}
+	return out
+}
+
+// MapMergeOrderedFunc merges 2 OrderedMaps using function to get the final value, like MapMergeFunc.
+// Resulting order has keys of the first map followed by keys present only in the second one
+// Returns a new map, inputs are unchanged
+func MapMergeOrderedFunc[K comparable, V any](mapFunc func(k K, v1 V, v2 V) V, M1, M2 *OrderedMap[K, V]) *OrderedMap[K, V] {
+	out := NewOrderedMap[K, V]()
+	for k, v := range M1.All() {
+		v2, _ := M2.Get(k)
+		out.Set(k, mapFunc(k, v, v2))
+	}
+	for k, v := range M2.All() {
+		if !out.Has(k) {
+			var v1 V
+			out.Set(k, mapFunc(k, v1, v))
+		}
+	}
+	return out
+}
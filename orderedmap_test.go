@@ -0,0 +1,183 @@
+package goneric
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("c", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+	m.Set("a", 4)
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys())
+	assert.Equal(t, []int{1, 4, 3}, m.Values())
+	assert.Equal(t, 3, m.Len())
+	v, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+	_, ok = m.Get("z")
+	assert.False(t, ok)
+	assert.True(t, m.Has("b"))
+
+	m.Delete("a")
+	m.Delete("nonexistent")
+	assert.False(t, m.Has("a"))
+	assert.Equal(t, []string{"c", "b"}, m.Keys())
+	m.Set("a", 5)
+	assert.Equal(t, []string{"c", "b", "a"}, m.Keys())
+	assert.Equal(t, map[string]int{"a": 5, "b": 3, "c": 1}, m.Map())
+
+	// deleting while iterating
+	for k := range m.All() {
+		m.Delete(k)
+	}
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, []string{}, m.Keys())
+
+	// deleting elements other than the current one while iterating
+	m = NewOrderedMap[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		m.Set(k, i)
+	}
+	var seen []string
+	for k := range m.All() {
+		seen = append(seen, k)
+		if k == "a" {
+			m.Delete("b")
+			m.Delete("c")
+		}
+		if k == "d" {
+			m.Delete("d")
+			m.Delete("e")
+		}
+	}
+	assert.Equal(t, []string{"a", "d"}, seen)
+	assert.Equal(t, []string{"a"}, m.Keys())
+}
+
+func TestOrderedMapZero(t *testing.T) {
+	var m OrderedMap[int, string]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, []int{}, m.Keys())
+	_, ok := m.Get(1)
+	assert.False(t, ok)
+	m.Delete(1)
+	m.Set(2, "b")
+	m.Set(1, "a")
+	assert.Equal(t, []int{2, 1}, m.Keys())
+}
+
+func TestOrderedMapFromMap(t *testing.T) {
+	m := OrderedMapFromMap(map[string]int{"b": 2, "a": 1, "c": 3}, func(l, r string) bool { return l < r })
+	assert.Equal(t, []string{"a", "b", "c"}, m.Keys())
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	m := NewOrderedMap[string, any]()
+	m.Set("zeta", 1)
+	m.Set("alpha", []int{1, 2})
+	m.Set("mid\"quote", map[string]int{"x": 1})
+	out, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"zeta":1,"alpha":[1,2],"mid\"quote":{"x":1}}`, string(out))
+
+	out, err = json.Marshal(NewOrderedMap[string, int]())
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(out))
+
+	var nilMap *OrderedMap[string, int]
+	out, err = json.Marshal(nilMap)
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(out))
+
+	in := NewOrderedMap[string, int]()
+	in.Set("old", 1)
+	assert.NoError(t, json.Unmarshal([]byte(`{"z": 1, "b": 2, "x\"y": 3, "b": 4}`), in))
+	assert.Equal(t, []string{"z", "b", "x\"y"}, in.Keys())
+	assert.Equal(t, []int{1, 4, 3}, in.Values())
+
+	// int keys follow encoding/json rules
+	ints := NewOrderedMap[int, string]()
+	assert.NoError(t, json.Unmarshal([]byte(`{"3":"c","1":"a"}`), ints))
+	assert.Equal(t, []int{3, 1}, ints.Keys())
+	out, err = json.Marshal(ints)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"3":"c","1":"a"}`, string(out))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"cat":"c"}`), ints))
+	assert.Error(t, json.Unmarshal([]byte(`[1,2]`), ints))
+	assert.Error(t, json.Unmarshal([]byte(`{"1":1}`), ints))
+
+	// nested in struct
+	type cfg struct {
+		Headers *OrderedMap[string, string] `json:"headers"`
+	}
+	c := cfg{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"headers":{"X-B":"1","X-A":"2"}}`), &c))
+	assert.Equal(t, []string{"X-B", "X-A"}, c.Headers.Keys())
+	out, err = json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"headers":{"X-B":"1","X-A":"2"}}`, string(out))
+}
+
+func TestMapOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	mapped := MapOrderedMap(func(k string, v int) (string, string) {
+		return strings.ToUpper(k), strconv.Itoa(v)
+	}, m)
+	assert.Equal(t, []string{"C", "A", "B"}, mapped.Keys())
+	assert.Equal(t, []string{"3", "1", "2"}, mapped.Values())
+
+	filtered := FilterOrderedMap(func(k string, v int) bool { return v > 1 }, m)
+	assert.Equal(t, []string{"c", "b"}, filtered.Keys())
+
+	m2 := NewOrderedMap[string, int]()
+	m2.Set("d", 10)
+	m2.Set("a", 20)
+	merged := MapMergeOrderedFunc(func(k string, v1, v2 int) int { return v1 + v2 }, m, m2)
+	assert.Equal(t, []string{"c", "a", "b", "d"}, merged.Keys())
+	assert.Equal(t, []int{3, 21, 2, 10}, merged.Values())
+
+	// plain map helpers work via Map()
+	assert.Equal(t, map[string]int{"c": 6, "a": 2, "b": 4}, MapMap(func(k string, v int) (string, int) { return k, v * 2 }, m.Map()))
+}
+
+func ExampleOrderedMap() {
+	headers := NewOrderedMap[string, string]()
+	headers.Set("Host", "example.com")
+	headers.Set("Accept", "*/*")
+	headers.Set("Content-Type", "text/plain")
+	out, _ := json.Marshal(headers)
+	fmt.Println(string(out))
+	// Output: {"Host":"example.com","Accept":"*/*","Content-Type":"text/plain"}
+}
+
+func TestOrderedMapJSONValueField(t *testing.T) {
+	type wrapper struct {
+		H OrderedMap[string, int]  `json:"h"`
+		P *OrderedMap[string, int] `json:"p"`
+	}
+	w := wrapper{}
+	w.H.Set("b", 1)
+	w.H.Set("a", 2)
+	byValue, err := json.Marshal(w)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"h":{"b":1,"a":2},"p":null}`, string(byValue))
+	byPointer, err := json.Marshal(&w)
+	assert.NoError(t, err)
+	assert.Equal(t, string(byValue), string(byPointer))
+
+	decoded := wrapper{}
+	assert.NoError(t, json.Unmarshal(byValue, &decoded))
+	assert.Equal(t, []string{"b", "a"}, decoded.H.Keys())
+	assert.Nil(t, decoded.P)
+}
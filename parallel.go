@@ -1,6 +1,8 @@
 package goneric
 
 import (
+	"context"
+	"errors"
 	"sync"
 )
 
@@ -128,3 +130,366 @@ func ParallelMapSliceChanFinisher[T1, T2 any](mapFunc func(T1) T2, concurrency i
 
 	return out, finisher
 }
+
+// ParallelMapSliceChanUnpanic works like ParallelMapSliceChan but recovers panics in function.
+// Element that caused the panic is skipped and the panic is sent to error channel as *PanicError with element index as a Key.
+// Both channels need to be read or else it will stall, both are closed when all elements are processed
+// will panic if concurrency is less than 1
+func ParallelMapSliceChanUnpanic[T1, T2 any](mapFunc func(T1) T2, concurrency int, slice []T1) (chan T2, chan error) {
+	errCh := make(chan error, 1)
+	out, done := parallelMapSliceChanUnpanic(mapFunc, concurrency, slice, func(err error) { errCh <- err })
+	go func() {
+		<-done
+		close(errCh)
+	}()
+	return out, errCh
+}
+
+// ParallelMapSliceChanFinisherUnpanic works like ParallelMapSliceChanFinisher but recovers panics in function.
+// Element that caused the panic is skipped. Second channel gets panics as *PanicError with element index as a Key,
+// joined via errors.Join (or nil if there were none), when all elements are processed, then closes
+// will panic if concurrency is less than 1
+func ParallelMapSliceChanFinisherUnpanic[T1, T2 any](mapFunc func(T1) T2, concurrency int, slice []T1) (chan T2, chan error) {
+	var errs []error
+	errLock := sync.Mutex{}
+	finisher := make(chan error, 1)
+	out, done := parallelMapSliceChanUnpanic(mapFunc, concurrency, slice, func(err error) {
+		errLock.Lock()
+		defer errLock.Unlock()
+		errs = append(errs, err)
+	})
+	go func() {
+		<-done
+		finisher <- errors.Join(errs...)
+		close(finisher)
+	}()
+	return out, finisher
+}
+
+// parallelMapSliceChanUnpanic feeds slice to function in parallel, passing recovered panics to onPanic.
+// done is closed after output is closed
+func parallelMapSliceChanUnpanic[T1, T2 any](
+	mapFunc func(T1) T2,
+	concurrency int,
+	slice []T1,
+	onPanic func(error),
+) (out chan T2, done chan struct{}) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	in := make(chan ValueIndex[T1], 1)
+	out = make(chan T2, concurrency/2+1)
+	done = make(chan struct{})
+	go func() {
+		for idx, v := range slice {
+			in <- ValueIndex[T1]{V: v, IDX: idx}
+		}
+		close(in)
+	}()
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for v := range in {
+				res, err := unpanic(v.IDX, mapFunc, v.V)
+				if err != nil {
+					onPanic(err)
+				} else {
+					out <- res
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+		close(done)
+	}()
+	return out, done
+}
+
+// ParallelMapChanOrdered runs elements from input channel thru function in parallel, up to `concurrency` goroutines,
+// sending results to output channel in input order.
+// At most `2*concurrency` elements are between being read from input and sent to output,
+// which caps the size of reorder buffer; one slow element stalls reading input until it finishes.
+// Caller should consume the whole output channel or else it will leak goroutines
+// close is propagated
+// will panic if concurrency is less than 1
+func ParallelMapChanOrdered[T1, T2 any](mapFunc func(T1) T2, concurrency int, in chan T1) (out chan T2) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	out = make(chan T2, concurrency/2+1)
+	// token per element in flight, returned when element is sent out
+	window := make(chan struct{}, concurrency*2)
+	inCh := make(chan ValueIndex[T1], concurrency/2+1)
+	outCh := make(chan ValueIndex[T2], concurrency/2+1)
+	go func() {
+		idx := 0
+		for v := range in {
+			window <- struct{}{}
+			inCh <- ValueIndex[T1]{V: v, IDX: idx}
+			idx++
+		}
+		close(inCh)
+	}()
+	go func() {
+		WorkerPool(
+			inCh,
+			outCh,
+			func(i ValueIndex[T1]) ValueIndex[T2] {
+				return ValueIndex[T2]{
+					V:   mapFunc(i.V),
+					IDX: i.IDX,
+				}
+			},
+			concurrency, true)
+	}()
+	go func() {
+		pending := make(map[int]T2, concurrency*2)
+		next := 0
+		for v := range outCh {
+			pending[v.IDX] = v.V
+			for {
+				res, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				out <- res
+				<-window
+				next++
+			}
+		}
+		close(out)
+	}()
+	return out
+}
+
+// ParallelMapSliceErr takes slice and runs it thru function in parallel, up to `concurrency` goroutines
+// First error cancels the context passed to the function and stops processing the rest of the slice.
+// Like MapSliceErr it returns ordered results for the elements before the first one that didn't complete,
+// so they can be shorter than the input even for elements that were fine, if they got cancelled
+// will panic if concurrency is less than 1
+func ParallelMapSliceErr[T1, T2 any](
+	ctx context.Context,
+	mapFunc func(context.Context, T1) (T2, error),
+	concurrency int,
+	slice []T1,
+) (out []T2, err error) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	out = make([]T2, len(slice))
+	completed := make([]bool, len(slice))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	inCh := make(chan ValueIndex[T1], concurrency/2+1)
+	outCh := make(chan ValueIndex[T2], concurrency/2+1)
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for v := range outCh {
+			out[v.IDX] = v.V
+			completed[v.IDX] = true
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		err = WorkerPoolErr(
+			ctx,
+			inCh,
+			outCh,
+			func(ctx context.Context, i ValueIndex[T1]) (ValueIndex[T2], error) {
+				v, err := mapFunc(ctx, i.V)
+				return ValueIndex[T2]{V: v, IDX: i.IDX}, err
+			},
+			concurrency, true)
+		// unblock the feeder if pool stopped early
+		cancel()
+	}()
+feed:
+	for idx, v := range slice {
+		select {
+		case inCh <- ValueIndex[T1]{V: v, IDX: idx}:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(inCh)
+	wg.Wait()
+	if err != nil {
+		for idx, ok := range completed {
+			if !ok {
+				return out[:idx], err
+			}
+		}
+	}
+	return out, err
+}
+
+// ParallelMapSliceErrAll takes slice and runs it thru function in parallel, up to `concurrency` goroutines
+// Unlike ParallelMapSliceErr it does not stop on error, every element is processed and
+// errors are returned joined via errors.Join, in order of elements that caused them.
+// Elements that failed have zero value in the output
+// will panic if concurrency is less than 1
+func ParallelMapSliceErrAll[T1, T2 any](
+	ctx context.Context,
+	mapFunc func(context.Context, T1) (T2, error),
+	concurrency int,
+	slice []T1,
+) (out []T2, err error) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	out = make([]T2, len(slice))
+	errs := make([]error, len(slice))
+	inCh := make(chan ValueIndex[T1], concurrency/2+1)
+	outCh := make(chan ValueIndex[T2], concurrency/2+1)
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for v := range outCh {
+			out[v.IDX] = v.V
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		err = WorkerPoolCtx(
+			ctx,
+			inCh,
+			outCh,
+			func(ctx context.Context, i ValueIndex[T1]) ValueIndex[T2] {
+				v, err := mapFunc(ctx, i.V)
+				if err != nil {
+					// each index is only ever touched by one worker
+					errs[i.IDX] = err
+					var zero T2
+					v = zero
+				}
+				return ValueIndex[T2]{V: v, IDX: i.IDX}
+			},
+			concurrency, true)
+	}()
+feed:
+	for idx, v := range slice {
+		select {
+		case inCh <- ValueIndex[T1]{V: v, IDX: idx}:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(inCh)
+	wg.Wait()
+	return out, errors.Join(append(errs, err)...)
+}
+
+// ParallelMapUnpanic works like ParallelMap but recovers panics in function.
+// Panics are returned as *PanicError with element index as a Key, joined via errors.Join
+// will panic if concurrency is less than 1
+func ParallelMapUnpanic[T1, T2 any](mapFunc func(T1) T2, concurrency int, slice ...T1) ([]T2, error) {
+	return ParallelMapSliceUnpanic(mapFunc, concurrency, slice)
+}
+
+// ParallelMapSliceUnpanic works like ParallelMapSlice but recovers panics in function.
+// Elements that panicked have zero value in the output, panics are returned
+// as *PanicError with element index as a Key, joined via errors.Join in input order
+// will panic if concurrency is less than 1
+func ParallelMapSliceUnpanic[T1, T2 any](mapFunc func(T1) T2, concurrency int, slice []T1) ([]T2, error) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	out := make([]T2, len(slice))
+	errs := make([]error, len(slice))
+	inCh := make(chan ValueIndex[T1], concurrency/2+1)
+	outCh := make(chan ValueIndex[T2], concurrency/2+1)
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for v := range outCh {
+			out[v.IDX] = v.V
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		WorkerPool(
+			inCh,
+			outCh,
+			func(i ValueIndex[T1]) ValueIndex[T2] {
+				v, err := unpanic(i.IDX, mapFunc, i.V)
+				// each index is only ever touched by one worker
+				errs[i.IDX] = err
+				return ValueIndex[T2]{V: v, IDX: i.IDX}
+			},
+			concurrency, true)
+	}()
+	for idx, v := range slice {
+		inCh <- ValueIndex[T1]{V: v, IDX: idx}
+	}
+	close(inCh)
+	wg.Wait()
+	return out, errors.Join(errs...)
+}
+
+// ParallelMapMapUnpanic works like ParallelMapMap but recovers panics in function.
+// Elements that panicked are skipped, panics are returned
+// as *PanicError with element key as a Key, joined via errors.Join
+// will panic if concurrency is less than 1
+func ParallelMapMapUnpanic[K1, K2 comparable, V1, V2 any](
+	mapFunc func(k K1, v V1) (K2, V2),
+	concurrency int,
+	in map[K1]V1,
+) (map[K2]V2, error) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	out := make(map[K2]V2, len(in))
+	var errs []error
+	errLock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	inCh := make(chan KeyValue[K1, V1], concurrency/2+1)
+	// nil marks element that panicked
+	outCh := make(chan *KeyValue[K2, V2], concurrency/2+1)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for v := range outCh {
+			if v != nil {
+				out[v.K] = v.V
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		WorkerPool(
+			inCh,
+			outCh,
+			func(i KeyValue[K1, V1]) *KeyValue[K2, V2] {
+				kv, err := unpanic(i.K, func(i KeyValue[K1, V1]) KeyValue[K2, V2] {
+					k, v := mapFunc(i.K, i.V)
+					return KeyValue[K2, V2]{K: k, V: v}
+				}, i)
+				if err != nil {
+					errLock.Lock()
+					errs = append(errs, err)
+					errLock.Unlock()
+					return nil
+				}
+				return &kv
+			},
+			concurrency, true)
+	}()
+	for k, v := range in {
+		inCh <- KeyValue[K1, V1]{K: k, V: v}
+	}
+	close(inCh)
+	wg.Wait()
+	return out, errors.Join(errs...)
+}
@@ -1,11 +1,14 @@
 package goneric
 
 import (
+	"context"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"testing/synctest"
 	"time"
 )
 
@@ -93,3 +96,143 @@ func TestParallelMapMap(t *testing.T) {
 		"d": "7",
 	}, mappedData)
 }
+
+func TestParallelMapSliceErr(t *testing.T) {
+	parse := func(_ context.Context, v string) (int, error) {
+		time.Sleep(time.Millisecond * time.Duration(rand.Int31n(10)))
+		return strconv.Atoi(v)
+	}
+	out, err := ParallelMapSliceErr(context.Background(), parse, 3, []string{"1", "3", "2", "7", "9", "12"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 2, 7, 9, 12}, out)
+
+	data := GenSlice(100, func(idx int) string { return strconv.Itoa(idx) })
+	data[50] = "cat"
+	out, err = ParallelMapSliceErr(context.Background(), parse, 4, data)
+	assert.Error(t, err)
+	assert.LessOrEqual(t, len(out), 50)
+	assert.Equal(t, GenSlice(len(out), func(idx int) int { return idx }), out)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	out, err = ParallelMapSliceErr(ctx, parse, 4, data)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, out)
+	assert.Panics(t, func() { ParallelMapSliceErr(context.Background(), parse, 0, data) })
+}
+
+func TestParallelMapSliceErrAll(t *testing.T) {
+	parse := func(_ context.Context, v string) (int, error) {
+		return strconv.Atoi(v)
+	}
+	out, err := ParallelMapSliceErrAll(context.Background(), parse, 3, []string{"1", "cat", "2", "dog", "9"})
+	assert.Equal(t, []int{1, 0, 2, 0, 9}, out)
+	assert.ErrorContains(t, err, `"cat"`)
+	assert.ErrorContains(t, err, `"dog"`)
+	assert.Regexp(t, `(?s)cat.*dog`, err.Error(), "errors should be in input order")
+
+	out, err = ParallelMapSliceErrAll(context.Background(), parse, 3, []string{"1", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, out)
+	assert.Panics(t, func() { ParallelMapSliceErrAll(context.Background(), parse, 0, []string{"1"}) })
+}
+
+func TestParallelMapUnpanic(t *testing.T) {
+	out, err := ParallelMapUnpanic(func(v string) int {
+		return Must(strconv.Atoi(v))
+	}, 3, "1", "cat", "3")
+	assert.Equal(t, []int{1, 0, 3}, out)
+	var pe *PanicError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, 1, pe.Key)
+		assert.ErrorIs(t, pe, strconv.ErrSyntax)
+	}
+
+	out, err = ParallelMapSliceUnpanic(func(v string) int {
+		return Must(strconv.Atoi(v))
+	}, 3, []string{"1", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, out)
+	assert.Panics(t, func() { ParallelMapSliceUnpanic(strconv.Itoa, 0, []int{1}) })
+	assert.Panics(t, func() { ParallelMapUnpanic(strconv.Itoa, -1, 1) })
+}
+
+func TestParallelMapSliceChanUnpanic(t *testing.T) {
+	out, errCh := ParallelMapSliceChanUnpanic(func(v string) int {
+		return Must(strconv.Atoi(v))
+	}, 2, []string{"1", "cat", "3", "dog"})
+	errs := make(chan []error, 1)
+	go func() { errs <- ChanToSlice(errCh) }()
+	assert.True(t, CompareSliceSet([]int{1, 3}, ChanToSlice(out)))
+	keys := MapSlice(func(err error) any { return err.(*PanicError).Key }, <-errs)
+	assert.True(t, CompareSliceSet([]any{1, 3}, keys))
+	assert.Panics(t, func() { ParallelMapSliceChanUnpanic(strconv.Itoa, 0, []int{1}) })
+}
+
+func TestParallelMapSliceChanFinisherUnpanic(t *testing.T) {
+	out, finisher := ParallelMapSliceChanFinisherUnpanic(func(v string) int {
+		return Must(strconv.Atoi(v))
+	}, 2, []string{"1", "2", "cat"})
+	assert.True(t, CompareSliceSet([]int{1, 2}, ChanToSlice(out)))
+	err := <-finisher
+	var pe *PanicError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, 2, pe.Key)
+	}
+	_, ok := <-finisher
+	assert.False(t, ok)
+
+	outStr, finisher := ParallelMapSliceChanFinisherUnpanic(strconv.Itoa, 3, []int{1, 2})
+	assert.Len(t, ChanToSlice(outStr), 2)
+	assert.NoError(t, <-finisher)
+	assert.Panics(t, func() { ParallelMapSliceChanFinisherUnpanic(strconv.Itoa, 0, []int{1}) })
+}
+
+func TestParallelMapMapUnpanic(t *testing.T) {
+	out, err := ParallelMapMapUnpanic(func(k string, v int) (string, int) {
+		return k, 10 / v
+	}, 2, map[string]int{"a": 1, "b": 0, "c": 5})
+	assert.Equal(t, map[string]int{"a": 10, "c": 2}, out)
+	var pe *PanicError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, "b", pe.Key)
+	}
+	assert.Panics(t, func() {
+		ParallelMapMapUnpanic(func(k string, v int) (string, int) { return k, v }, 0, map[string]int{"a": 1})
+	})
+}
+
+func TestParallelMapChanOrdered(t *testing.T) {
+	data := GenSlice(200, func(idx int) int { return idx })
+	out := ParallelMapChanOrdered(func(v int) string {
+		time.Sleep(time.Microsecond * time.Duration(rand.Intn(100)))
+		return strconv.Itoa(v)
+	}, 8, GenSliceToChan(data, true))
+	assert.Equal(t, MapSlice(strconv.Itoa, data), ChanToSlice(out))
+	assert.Empty(t, ChanToSlice(ParallelMapChanOrdered(strconv.Itoa, 2, GenSliceToChan([]int{}, true))))
+	assert.Panics(t, func() { ParallelMapChanOrdered(strconv.Itoa, 0, make(chan int)) })
+}
+
+func TestParallelMapChanOrderedWindow(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var started atomic.Int64
+		in := make(chan int)
+		go func() {
+			for i := range 100 {
+				in <- i
+			}
+			close(in)
+		}()
+		out := ParallelMapChanOrdered(func(v int) int {
+			started.Add(1)
+			if v == 0 {
+				// first element is slow, everything else has to wait in reorder buffer
+				time.Sleep(time.Second)
+			}
+			return v
+		}, 4, in)
+		synctest.Wait()
+		assert.Equal(t, int64(8), started.Load(), "input should not be read past the window")
+		assert.Equal(t, GenSlice(100, func(idx int) int { return idx }), ChanToSlice(out))
+	})
+}
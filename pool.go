@@ -0,0 +1,243 @@
+package goneric
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Pool is a worker pool that can be resized at runtime and reports its statistics.
+// Like WorkerPoolAsync, every submitted job gets its own result channel.
+// Panics in worker are recovered and counted as errors, the result channel of such job is closed without value,
+// so check for `v, ok := <-ch` if worker can panic. Result channels are closed after sending the result.
+// It is safe for concurrent use
+type Pool[T1, T2 any] struct {
+	worker func(T1) T2
+	input  chan Response[T1, T2]
+	// closed by Close to unblock pending submits
+	closing chan struct{}
+	// closed after Close made sure nothing else will be queued, workers then finish the queue and exit
+	drain chan struct{}
+	// closed when Shutdown deadline passes, workers exit without finishing the queue
+	abort chan struct{}
+	// closed after all workers exit
+	done       chan struct{}
+	submitLock sync.RWMutex
+	closeOnce  sync.Once
+	abortOnce  sync.Once
+
+	workersLock sync.Mutex
+	stops       []chan struct{}
+	closed      bool
+	wg          sync.WaitGroup
+
+	workers      atomic.Int64
+	queued       atomic.Int64
+	inFlight     atomic.Int64
+	completed    atomic.Int64
+	errors       atomic.Int64
+	latencyTotal atomic.Int64
+	lastError    atomic.Pointer[PanicError]
+}
+
+// PoolStats is a snapshot of Pool statistics
+type PoolStats struct {
+	// Workers is number of running worker goroutines
+	Workers int
+	// Queued is number of jobs waiting for a worker
+	Queued int
+	// InFlight is number of jobs being processed
+	InFlight int
+	// Completed is number of finished jobs, including failed ones
+	Completed int
+	// Errors is number of jobs where worker panicked
+	Errors int
+	// LastError is the most recent recovered panic, nil if there were none
+	LastError error
+	// AvgLatency is average time worker spent on a job
+	AvgLatency time.Duration
+}
+
+// NewPool creates Pool with `concurrency` workers and queue of `concurrency/2+1` jobs
+// will panic if concurrency is less than 1
+func NewPool[T1, T2 any](worker func(T1) T2, concurrency int) *Pool[T1, T2] {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	p := &Pool[T1, T2]{
+		worker:  worker,
+		input:   make(chan Response[T1, T2], concurrency/2+1),
+		closing: make(chan struct{}),
+		drain:   make(chan struct{}),
+		abort:   make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	p.Resize(concurrency)
+	return p
+}
+
+// Submit queues job and returns channel that will receive the result.
+// Blocks if queue is full, returns ErrPoolClosed after pool was closed
+func (p *Pool[T1, T2]) Submit(in T1) (chan T2, error) {
+	p.submitLock.RLock()
+	defer p.submitLock.RUnlock()
+	select {
+	case <-p.closing:
+		return nil, ErrPoolClosed{}
+	default:
+	}
+	ch := make(chan T2, 1)
+	p.queued.Add(1)
+	select {
+	case p.input <- Response[T1, T2]{ReturnCh: ch, Data: in}:
+		return ch, nil
+	case <-p.closing:
+		p.queued.Add(-1)
+		return nil, ErrPoolClosed{}
+	}
+}
+
+// Resize changes number of workers. Removed workers finish the job they are running before exiting.
+// Resizing closed pool does nothing
+// will panic if n is less than 1
+func (p *Pool[T1, T2]) Resize(n int) {
+	if n < 1 {
+		panic("RTFM")
+	}
+	p.workersLock.Lock()
+	defer p.workersLock.Unlock()
+	if p.closed {
+		return
+	}
+	for len(p.stops) < n {
+		stop := make(chan struct{})
+		p.stops = append(p.stops, stop)
+		p.wg.Add(1)
+		p.workers.Add(1)
+		go p.work(stop)
+	}
+	for len(p.stops) > n {
+		close(p.stops[len(p.stops)-1])
+		p.stops = p.stops[:len(p.stops)-1]
+	}
+}
+
+// Close stops accepting new jobs, already queued ones are still processed. It does not wait for them, use Wait for that.
+// Calling it more than once is safe
+func (p *Pool[T1, T2]) Close() {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+		// wait for submits that are in progress to give up
+		p.submitLock.Lock()
+		p.submitLock.Unlock()
+		p.workersLock.Lock()
+		p.closed = true
+		p.workersLock.Unlock()
+		close(p.drain)
+		go func() {
+			p.wg.Wait()
+			p.dropQueued()
+			close(p.done)
+		}()
+	})
+}
+
+// Wait blocks until pool is closed and all workers finish
+func (p *Pool[T1, T2]) Wait() {
+	<-p.done
+}
+
+// Shutdown closes the pool and waits for queued jobs to finish.
+// If context is cancelled first, workers stop taking new jobs, result channels of jobs still in the queue are closed without value,
+// and ctx.Err() is returned. Jobs that are already running can't be interrupted and finish in the background
+func (p *Pool[T1, T2]) Shutdown(ctx context.Context) error {
+	p.Close()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.abortOnce.Do(func() { close(p.abort) })
+		p.dropQueued()
+		return ctx.Err()
+	}
+}
+
+// Stats returns snapshot of pool statistics
+func (p *Pool[T1, T2]) Stats() PoolStats {
+	s := PoolStats{
+		Workers:   int(p.workers.Load()),
+		Queued:    int(p.queued.Load()),
+		InFlight:  int(p.inFlight.Load()),
+		Completed: int(p.completed.Load()),
+		Errors:    int(p.errors.Load()),
+	}
+	if err := p.lastError.Load(); err != nil {
+		s.LastError = err
+	}
+	if s.Completed > 0 {
+		s.AvgLatency = time.Duration(p.latencyTotal.Load() / int64(s.Completed))
+	}
+	return s
+}
+
+func (p *Pool[T1, T2]) work(stop chan struct{}) {
+	defer p.wg.Done()
+	defer p.workers.Add(-1)
+	for {
+		// select picks at random so check first, to not pull more work after abort
+		select {
+		case <-p.abort:
+			return
+		default:
+		}
+		select {
+		case <-stop:
+			return
+		case <-p.abort:
+			return
+		case job := <-p.input:
+			p.run(job)
+		case <-p.drain:
+			// nothing new can be queued now, finish what's left
+			select {
+			case <-stop:
+				return
+			case job := <-p.input:
+				p.run(job)
+			default:
+				return
+			}
+		}
+	}
+}
+
+func (p *Pool[T1, T2]) run(job Response[T1, T2]) {
+	p.queued.Add(-1)
+	p.inFlight.Add(1)
+	start := time.Now()
+	out, err := unpanic(job.Data, p.worker, job.Data)
+	p.latencyTotal.Add(int64(time.Since(start)))
+	p.inFlight.Add(-1)
+	p.completed.Add(1)
+	if err != nil {
+		p.errors.Add(1)
+		p.lastError.Store(err.(*PanicError))
+	} else {
+		job.ReturnCh <- out
+	}
+	close(job.ReturnCh)
+}
+
+// dropQueued closes result channels of jobs that will never run
+func (p *Pool[T1, T2]) dropQueued() {
+	for {
+		select {
+		case job := <-p.input:
+			p.queued.Add(-1)
+			close(job.ReturnCh)
+		default:
+			return
+		}
+	}
+}
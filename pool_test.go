@@ -0,0 +1,150 @@
+package goneric
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	p := NewPool(func(v int) int { return v * 2 }, 3)
+	var results []chan int
+	for i := range 10 {
+		ch, err := p.Submit(i)
+		assert.NoError(t, err)
+		results = append(results, ch)
+	}
+	for i, ch := range results {
+		assert.Equal(t, i*2, <-ch)
+	}
+	p.Close()
+	p.Wait()
+	_, err := p.Submit(1)
+	assert.ErrorIs(t, err, ErrPoolClosed{})
+	stats := p.Stats()
+	assert.Equal(t, 10, stats.Completed)
+	assert.Equal(t, 0, stats.Workers)
+	assert.Equal(t, 0, stats.Queued)
+	assert.Equal(t, 0, stats.Errors)
+	assert.Nil(t, stats.LastError)
+	assert.Panics(t, func() { NewPool(func(v int) int { return v }, 0) })
+}
+
+func TestPoolResize(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		p := NewPool(func(v int) int { time.Sleep(time.Second); return v }, 1)
+		submit := func(n int) (out []chan int) {
+			for i := range n {
+				ch, err := p.Submit(i)
+				assert.NoError(t, err)
+				out = append(out, ch)
+			}
+			return out
+		}
+		start := time.Now()
+		for _, ch := range submit(2) {
+			<-ch
+		}
+		assert.Equal(t, time.Second*2, time.Since(start))
+
+		p.Resize(4)
+		synctest.Wait()
+		assert.Equal(t, 4, p.Stats().Workers)
+		start = time.Now()
+		for _, ch := range submit(4) {
+			<-ch
+		}
+		assert.Equal(t, time.Second, time.Since(start))
+
+		p.Resize(2)
+		synctest.Wait()
+		assert.Equal(t, 2, p.Stats().Workers)
+		start = time.Now()
+		for _, ch := range submit(4) {
+			<-ch
+		}
+		assert.Equal(t, time.Second*2, time.Since(start))
+		assert.Panics(t, func() { p.Resize(0) })
+
+		assert.NoError(t, p.Shutdown(context.Background()))
+		p.Resize(3)
+		assert.Equal(t, 0, p.Stats().Workers, "resizing closed pool should do nothing")
+	})
+}
+
+func TestPoolStats(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		release := make(chan bool)
+		p := NewPool(func(v int) int {
+			<-release
+			time.Sleep(time.Second * time.Duration(v))
+			if v == 0 {
+				panic("zero")
+			}
+			return v
+		}, 2)
+		var results []chan int
+		for _, v := range []int{1, 3, 0} {
+			ch, err := p.Submit(v)
+			assert.NoError(t, err)
+			results = append(results, ch)
+		}
+		synctest.Wait()
+		stats := p.Stats()
+		assert.Equal(t, 2, stats.InFlight)
+		assert.Equal(t, 1, stats.Queued)
+		close(release)
+		assert.Equal(t, 1, <-results[0])
+		assert.Equal(t, 3, <-results[1])
+		_, ok := <-results[2]
+		assert.False(t, ok, "panicked job should have its channel closed")
+		stats = p.Stats()
+		assert.Equal(t, 3, stats.Completed)
+		assert.Equal(t, 1, stats.Errors)
+		assert.ErrorContains(t, stats.LastError, "zero")
+		assert.Equal(t, 0, stats.InFlight)
+		assert.Equal(t, 0, stats.Queued)
+		assert.Equal(t, time.Second*4/3, stats.AvgLatency)
+		p.Close()
+		p.Wait()
+	})
+}
+
+func TestPoolShutdown(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		p := NewPool(func(v int) int { time.Sleep(time.Second); return v }, 1)
+		var results []chan int
+		for i := range 2 {
+			ch, err := p.Submit(i)
+			assert.NoError(t, err)
+			results = append(results, ch)
+		}
+		// queued jobs are finished before shutdown returns
+		assert.NoError(t, p.Shutdown(context.Background()))
+		assert.Equal(t, 0, <-results[0])
+		assert.Equal(t, 1, <-results[1])
+		assert.Equal(t, 2, p.Stats().Completed)
+	})
+	synctest.Test(t, func(t *testing.T) {
+		p := NewPool(func(v int) int { time.Sleep(time.Second); return v }, 1)
+		var results []chan int
+		for i := range 2 {
+			ch, err := p.Submit(i)
+			assert.NoError(t, err)
+			results = append(results, ch)
+		}
+		synctest.Wait()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*500)
+		defer cancel()
+		assert.ErrorIs(t, p.Shutdown(ctx), context.DeadlineExceeded)
+		_, ok := <-results[1]
+		assert.False(t, ok, "queued job should be dropped")
+		// running job still finishes
+		assert.Equal(t, 0, <-results[0])
+		p.Wait()
+		assert.Equal(t, 1, p.Stats().Completed)
+		assert.Equal(t, 0, p.Stats().Queued)
+	})
+}
@@ -0,0 +1,152 @@
+package goneric
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket rate limiter. Bucket holds up to `burst` tokens and refills at `rate` tokens per second,
+// each call takes one token. It starts full, so first `burst` calls are not delayed.
+// It is safe for concurrent use, waiting callers are served in order of calling
+type RateLimiter struct {
+	lock   sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// number of reservations made so far, to tell whether cancelled one was the last
+	reserved uint64
+}
+
+// NewRateLimiter creates RateLimiter allowing `rate` calls per second with bursts of up to `burst` calls
+// will panic if rate is not positive or burst is less than 1
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return NewRateLimiterClock(RealClock{}, rate, burst)
+}
+
+// NewRateLimiterClock is NewRateLimiter using provided Clock
+func NewRateLimiterClock(clock Clock, rate float64, burst int) *RateLimiter {
+	if rate <= 0 || burst < 1 {
+		panic("RTFM")
+	}
+	return &RateLimiter{
+		clock:  clock,
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// Allow takes a token if one is available without waiting, returns false otherwise
+func (r *RateLimiter) Allow() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.refill()
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// Wait blocks until token is available and takes it
+func (r *RateLimiter) Wait() {
+	if d, _ := r.reserve(); d > 0 {
+		r.clock.Sleep(d)
+	}
+}
+
+// WaitCtx blocks until token is available and takes it, or until context is cancelled.
+// Returns ctx.Err() if cancelled, in which case token is given back if no one reserved a token after it.
+// Later reservations already have their slots, giving it back then would let two callers share one
+func (r *RateLimiter) WaitCtx(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	d, seq := r.reserve()
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		r.lock.Lock()
+		if r.reserved == seq {
+			r.tokens = min(r.tokens+1, r.burst)
+		}
+		r.lock.Unlock()
+		return ctx.Err()
+	case <-r.clock.After(d):
+		return nil
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long caller has to wait for it
+// and sequence number of the reservation
+func (r *RateLimiter) reserve() (wait time.Duration, seq uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.refill()
+	r.tokens--
+	r.reserved++
+	if r.tokens >= 0 {
+		return 0, r.reserved
+	}
+	return time.Duration(math.Ceil(-r.tokens / r.rate * float64(time.Second))), r.reserved
+}
+
+func (r *RateLimiter) refill() {
+	now := r.clock.Now()
+	if elapsed := now.Sub(r.last); elapsed > 0 {
+		r.tokens = min(r.burst, r.tokens+elapsed.Seconds()*r.rate)
+	}
+	r.last = now
+}
+
+// RateLimitFunc wraps function so every call waits for the limiter first
+func RateLimitFunc[T1, T2 any](limiter *RateLimiter, f func(T1) T2) func(T1) T2 {
+	return func(v T1) T2 {
+		limiter.Wait()
+		return f(v)
+	}
+}
+
+// RateLimitGen wraps generator function so every call waits for the limiter first.
+// Use it with GenChan/ChanGen family to generate values at limited pace
+func RateLimitGen[T any](limiter *RateLimiter, genFunc func() T) func() T {
+	return func() T {
+		limiter.Wait()
+		return genFunc()
+	}
+}
+
+// WorkerPoolRateLimit is WorkerPool with worker calls limited by the limiter across all goroutines
+func WorkerPoolRateLimit[T1, T2 any](
+	input chan T1,
+	output chan T2,
+	worker func(T1) T2,
+	concurrency int,
+	limiter *RateLimiter,
+	closeOutputChan ...bool,
+) {
+	WorkerPool(input, output, RateLimitFunc(limiter, worker), concurrency, closeOutputChan...)
+}
+
+// WorkerPoolBackgroundRateLimit is WorkerPoolBackground with worker calls limited by the limiter across all goroutines
+func WorkerPoolBackgroundRateLimit[T1, T2 any](
+	input chan T1,
+	worker func(T1) T2,
+	concurrency int,
+	limiter *RateLimiter,
+	closeOutputChan ...bool,
+) (output chan T2) {
+	return WorkerPoolBackground(input, RateLimitFunc(limiter, worker), concurrency, closeOutputChan...)
+}
+
+// ParallelMapSliceRateLimit is ParallelMapSlice with mapFunc calls limited by the limiter across all goroutines
+func ParallelMapSliceRateLimit[T1, T2 any](mapFunc func(T1) T2, concurrency int, limiter *RateLimiter, slice []T1) []T2 {
+	return ParallelMapSlice(RateLimitFunc(limiter, mapFunc), concurrency, slice)
+}
@@ -0,0 +1,135 @@
+package goneric
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	r := NewRateLimiterClock(clock, 10, 3)
+	assert.True(t, r.Allow())
+	assert.True(t, r.Allow())
+	assert.True(t, r.Allow())
+	assert.False(t, r.Allow(), "burst should be used up")
+	clock.Advance(time.Millisecond * 150)
+	assert.True(t, r.Allow())
+	assert.False(t, r.Allow())
+	clock.Advance(time.Hour)
+	for range 3 {
+		assert.True(t, r.Allow())
+	}
+	assert.False(t, r.Allow(), "bucket should not fill above burst")
+
+	assert.Panics(t, func() { NewRateLimiter(0, 1) })
+	assert.Panics(t, func() { NewRateLimiter(1, 0) })
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		r := NewRateLimiter(10, 2)
+		start := time.Now()
+		for range 6 {
+			r.Wait()
+		}
+		// 2 from burst, then 4 at 100ms intervals
+		assert.Equal(t, time.Millisecond*400, time.Since(start))
+	})
+}
+
+func TestRateLimiterWaitCtx(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		r := NewRateLimiter(1, 1)
+		assert.NoError(t, r.WaitCtx(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+		assert.ErrorIs(t, r.WaitCtx(ctx), context.DeadlineExceeded)
+		assert.ErrorIs(t, r.WaitCtx(ctx), context.DeadlineExceeded)
+		// cancelled waits should give their tokens back
+		start := time.Now()
+		assert.NoError(t, r.WaitCtx(context.Background()))
+		assert.Equal(t, time.Millisecond*900, time.Since(start))
+	})
+}
+
+func TestRateLimiterWaitCtxCancelMiddle(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		r := NewRateLimiter(1, 1)
+		start := time.Now()
+		fired := make(chan time.Duration, 3)
+		wg := sync.WaitGroup{}
+		wait := func(ctx context.Context) {
+			if r.WaitCtx(ctx) == nil {
+				fired <- time.Since(start)
+			}
+		}
+		// first one takes the burst token, next ones get slots at 1s and 2s
+		wait(context.Background())
+		ctx, cancel := context.WithCancel(context.Background())
+		wg.Go(func() { wait(ctx) })
+		synctest.Wait()
+		wg.Go(func() { wait(context.Background()) })
+		synctest.Wait()
+		time.Sleep(time.Millisecond * 500)
+		cancel()
+		synctest.Wait()
+		// cancelled slot is not the last one so it can't be reused, new caller has to queue after the 2s one
+		wg.Go(func() { wait(context.Background()) })
+		wg.Wait()
+		close(fired)
+		var got []time.Duration
+		for d := range fired {
+			got = append(got, d)
+		}
+		assert.Equal(t, []time.Duration{0, time.Second * 2, time.Second * 3}, got)
+	})
+}
+
+func TestParallelMapSliceRateLimit(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		start := time.Now()
+		out := ParallelMapSliceRateLimit(
+			func(v int) int { return v * 2 },
+			4,
+			NewRateLimiter(100, 5),
+			[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		)
+		assert.Equal(t, []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20}, out)
+		assert.Equal(t, time.Millisecond*50, time.Since(start))
+	})
+}
+
+func TestWorkerPoolRateLimit(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		start := time.Now()
+		in := GenSliceToChan([]int{1, 2, 3, 4}, true)
+		out := make(chan int, 4)
+		WorkerPoolRateLimit(in, out, func(v int) int { return v + 1 }, 2, NewRateLimiter(10, 1), true)
+		assert.ElementsMatch(t, []int{2, 3, 4, 5}, ChanToSlice(out))
+		assert.Equal(t, time.Millisecond*300, time.Since(start))
+
+		start = time.Now()
+		out = WorkerPoolBackgroundRateLimit(GenSliceToChan([]int{1, 2, 3}, true), func(v int) int { return v }, 3, NewRateLimiter(10, 1), true)
+		assert.ElementsMatch(t, []int{1, 2, 3}, ChanToSlice(out))
+		assert.Equal(t, time.Millisecond*200, time.Since(start))
+	})
+}
+
+func TestRateLimitGen(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var ctr atomic.Int64
+		ch, closer := GenChanCloser(RateLimitGen(NewRateLimiter(20, 1), func() int64 { return ctr.Add(1) }))
+		start := time.Now()
+		assert.Equal(t, []int64{1, 2, 3, 4}, ChanToSliceN(ch, 4))
+		// buffered channel lets generator run one step ahead of the consumer
+		assert.Equal(t, time.Millisecond*150, time.Since(start))
+		closer(true)
+		for range ch {
+		}
+	})
+}
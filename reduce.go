@@ -0,0 +1,135 @@
+package goneric
+
+// Reduce reduces the list of variadic(...) values to single value, passing accumulator and each element to function.
+// It is provided as convenience, ReduceSlice() should be used when you have incoming slice
+func Reduce[T, A any](reduceFunc func(acc A, v T) A, init A, slice ...T) A {
+	return ReduceSlice(reduceFunc, init, slice)
+}
+
+// ReduceSlice reduces slice to single value, passing accumulator and each element to function
+func ReduceSlice[T, A any](reduceFunc func(acc A, v T) A, init A, slice []T) A {
+	acc := init
+	for _, v := range slice {
+		acc = reduceFunc(acc, v)
+	}
+	return acc
+}
+
+// ReduceMap reduces map to single value, passing accumulator and each key/value pair to function
+// Order is random, as with ranging over map
+func ReduceMap[K comparable, V, A any](reduceFunc func(acc A, k K, v V) A, init A, in map[K]V) A {
+	acc := init
+	for k, v := range in {
+		acc = reduceFunc(acc, k, v)
+	}
+	return acc
+}
+
+// ReduceChan reduces channel messages to single value, passing accumulator and each message to function
+// Returns after channel is closed
+func ReduceChan[T, A any](reduceFunc func(acc A, v T) A, init A, inCh chan T) A {
+	acc := init
+	for v := range inCh {
+		acc = reduceFunc(acc, v)
+	}
+	return acc
+}
+
+// FoldRight works like ReduceSlice but goes from the last element to the first
+func FoldRight[T, A any](reduceFunc func(acc A, v T) A, init A, slice []T) A {
+	acc := init
+	for i := len(slice) - 1; i >= 0; i-- {
+		acc = reduceFunc(acc, slice[i])
+	}
+	return acc
+}
+
+// Scan works like ReduceSlice but returns accumulator value after every element
+// `Scan(add, 0, []int{1,2,3}) -> []int{1,3,6}`
+func Scan[T, A any](reduceFunc func(acc A, v T) A, init A, slice []T) (out []A) {
+	out = make([]A, len(slice))
+	acc := init
+	for idx, v := range slice {
+		acc = reduceFunc(acc, v)
+		out[idx] = acc
+	}
+	return out
+}
+
+// ReduceErr reduces the list of variadic(...) values via function, returning on first error
+// Returns accumulator from before the failure
+func ReduceErr[T, A any](reduceFunc func(acc A, v T) (A, error), init A, slice ...T) (A, error) {
+	return ReduceSliceErr(reduceFunc, init, slice)
+}
+
+// ReduceSliceErr reduces slice via function, returning on first error
+// Returns accumulator from before the failure
+func ReduceSliceErr[T, A any](reduceFunc func(acc A, v T) (A, error), init A, slice []T) (A, error) {
+	acc := init
+	for _, v := range slice {
+		next, err := reduceFunc(acc, v)
+		if err != nil {
+			return acc, err
+		}
+		acc = next
+	}
+	return acc, nil
+}
+
+// ReduceMapErr reduces map via function, returning on first error
+// Returns accumulator from before the failure
+func ReduceMapErr[K comparable, V, A any](reduceFunc func(acc A, k K, v V) (A, error), init A, in map[K]V) (A, error) {
+	acc := init
+	for k, v := range in {
+		next, err := reduceFunc(acc, k, v)
+		if err != nil {
+			return acc, err
+		}
+		acc = next
+	}
+	return acc, nil
+}
+
+// ReduceChanErr reduces channel messages via function, returning on first error
+// Returns accumulator from before the failure. On error rest of the messages are left in the channel
+func ReduceChanErr[T, A any](reduceFunc func(acc A, v T) (A, error), init A, inCh chan T) (A, error) {
+	acc := init
+	for v := range inCh {
+		next, err := reduceFunc(acc, v)
+		if err != nil {
+			return acc, err
+		}
+		acc = next
+	}
+	return acc, nil
+}
+
+// FoldRightErr works like ReduceSliceErr but goes from the last element to the first
+// Returns accumulator from before the failure
+func FoldRightErr[T, A any](reduceFunc func(acc A, v T) (A, error), init A, slice []T) (A, error) {
+	acc := init
+	for i := len(slice) - 1; i >= 0; i-- {
+		next, err := reduceFunc(acc, slice[i])
+		if err != nil {
+			return acc, err
+		}
+		acc = next
+	}
+	return acc, nil
+}
+
+// ScanErr works like Scan, returning on first error
+// Returns slice with accumulator values of elements that didn't return error before the failure
+// so index of the first element in error is essentially `slice[len(out)]`
+func ScanErr[T, A any](reduceFunc func(acc A, v T) (A, error), init A, slice []T) (out []A, err error) {
+	out = make([]A, len(slice))
+	acc := init
+	for idx, v := range slice {
+		acc, err = reduceFunc(acc, v)
+		if err != nil {
+			return out[:idx], err
+		}
+		out[idx] = acc
+	}
+	return out, nil
+}
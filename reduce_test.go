@@ -0,0 +1,92 @@
+package goneric
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func reduceAdd(acc int, v int) int { return acc + v }
+
+func reduceAddStr(acc int, v string) (int, error) {
+	i, err := strconv.Atoi(v)
+	return acc + i, err
+}
+
+func TestReduce(t *testing.T) {
+	assert.Equal(t, 6, Reduce(reduceAdd, 0, 1, 2, 3))
+	assert.Equal(t, 10, Reduce(reduceAdd, 10))
+	assert.Equal(t, "abc", ReduceSlice(func(acc string, v string) string { return acc + v }, "", []string{"a", "b", "c"}))
+	assert.Equal(t, 6, ReduceMap(func(acc int, k string, v int) int { return acc + len(k)*v }, 0, map[string]int{"a": 1, "bb": 1, "ccc": 1}))
+	assert.Equal(t, 10, ReduceChan(reduceAdd, 0, GenChanN(func(idx int) int { return idx }, 5, true)))
+}
+
+func TestFoldRight(t *testing.T) {
+	assert.Equal(t, "cba", FoldRight(func(acc string, v string) string { return acc + v }, "", []string{"a", "b", "c"}))
+	assert.Equal(t, 0, FoldRight(reduceAdd, 0, []int{}))
+}
+
+func TestScan(t *testing.T) {
+	assert.Equal(t, []int{1, 3, 6, 10}, Scan(reduceAdd, 0, []int{1, 2, 3, 4}))
+	assert.Equal(t, []int{}, Scan(reduceAdd, 0, []int{}))
+}
+
+func TestReduceErr(t *testing.T) {
+	out, err := ReduceErr(reduceAddStr, 0, "1", "2", "3")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, out)
+	out, err = ReduceErr(reduceAddStr, 0, "1", "2", "cat", "3")
+	assert.Error(t, err)
+	assert.Equal(t, 3, out)
+
+	out, err = ReduceSliceErr(reduceAddStr, 0, []string{"1", "cat"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, out)
+
+	failErr := errors.New("fail")
+	out, err = ReduceMapErr(func(acc int, k string, v int) (int, error) {
+		if v == 0 {
+			return 0, failErr
+		}
+		return acc + v, nil
+	}, 0, map[string]int{"a": 1, "b": 0})
+	assert.ErrorIs(t, err, failErr)
+	out, err = ReduceMapErr(func(acc int, k string, v int) (int, error) { return acc + v, nil }, 0, map[string]int{"a": 1, "b": 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, out)
+
+	ch := GenChanN(func(idx int) string { return []string{"1", "2", "cat", "4"}[idx] }, 4, true)
+	out, err = ReduceChanErr(reduceAddStr, 0, ch)
+	assert.Error(t, err)
+	assert.Equal(t, 3, out)
+	assert.Equal(t, "4", <-ch, "rest should be left in channel")
+	out, err = ReduceChanErr(reduceAddStr, 0, GenChanN(func(idx int) string { return strconv.Itoa(idx) }, 4, true))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, out)
+}
+
+func TestFoldRightErr(t *testing.T) {
+	out, err := FoldRightErr(reduceAddStr, 0, []string{"cat", "1", "2"})
+	assert.Error(t, err)
+	assert.Equal(t, 3, out)
+	out, err = FoldRightErr(reduceAddStr, 0, []string{"1", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, out)
+}
+
+func TestScanErr(t *testing.T) {
+	out, err := ScanErr(reduceAddStr, 0, []string{"1", "2", "cat", "4"})
+	assert.Error(t, err)
+	assert.Equal(t, []int{1, 3}, out)
+	out, err = ScanErr(reduceAddStr, 0, []string{"1", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, out)
+}
+
+func ExampleScan() {
+	deposits := []int{100, -20, 50, -30}
+	fmt.Println(Scan(func(balance int, v int) int { return balance + v }, 0, deposits))
+	// Output: [100 80 130 100]
+}
@@ -1,12 +1,42 @@
 package goneric
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
-// Retry runs function until it succeeds, making up to n calls total
+// Permanent wraps error in PermanentError, making retry functions return it immediately instead of retrying.
+// Wrapped error is still reachable via errors.Is/errors.As. Returns nil for nil error
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return PermanentError{Err: err}
+}
+
+// IsPermanent checks whether error or any error it wraps was marked via Permanent
+func IsPermanent(err error) bool {
+	var p PermanentError
+	return errors.As(err, &p)
+}
+
+// RetryAttempt returns number (starting from 1) of current attempt from context passed by RetryCtx,
+// or 0 if context did not come from RetryCtx
+func RetryAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(retryAttemptKey{}).(int)
+	return attempt
+}
+
+type retryAttemptKey struct{}
+
+// Retry runs function until it succeeds, making up to n calls total.
+// Error marked via Permanent stops retrying immediately
 func Retry[T any](n int, f func() (T, error)) (T, error) {
 	for i := 1; i < n; i++ {
 		out, err := f()
-		if err == nil {
+		if err == nil || IsPermanent(err) {
 			return out, err
 		}
 	}
@@ -16,28 +46,40 @@ func Retry[T any](n int, f func() (T, error)) (T, error) {
 // RetryAfter retries function till it returns without error, first after min_interval,
 // then with increasing intervals up to max_interval with last retry happening near total_timeout
 // Intended use is to be able to say "retry for 10 minutes, at the very least every minute, but not shorter than 10 seconds to account for TCP retransmissions"
+// Error marked via Permanent stops retrying immediately
 func RetryAfter[T any](
 	min_interval,
 	max_interval,
 	total_timeout time.Duration,
 	f func() (T, error),
 ) (T, error) {
-	finish := time.Now().Add(total_timeout)
+	return RetryAfterClock(RealClock{}, min_interval, max_interval, total_timeout, f)
+}
+
+// RetryAfterClock is RetryAfter using provided Clock for time measurement and waiting
+func RetryAfterClock[T any](
+	clock Clock,
+	min_interval,
+	max_interval,
+	total_timeout time.Duration,
+	f func() (T, error),
+) (T, error) {
+	finish := clock.Now().Add(total_timeout)
 	interval := min_interval
 	var commandDuration time.Duration
 	for {
-		start := time.Now()
+		start := clock.Now()
 		out, err := f()
-		if err == nil {
+		if err == nil || IsPermanent(err) {
 			return out, err
 		}
-		commandDuration = time.Now().Sub(start)
-		time.Sleep(interval)
+		commandDuration = clock.Now().Sub(start)
+		clock.Sleep(interval)
 		interval = Min(max_interval, (interval * 3 / 2))
 		if start.Add(interval).Add(commandDuration).After(finish) {
 			// if we're near finish we will just try to run command at last second
-			ttf := finish.Sub(time.Now())
-			time.Sleep(ttf)
+			ttf := finish.Sub(clock.Now())
+			clock.Sleep(ttf)
 			break
 		}
 
@@ -45,6 +87,104 @@ func RetryAfter[T any](
 	return f()
 }
 
+// RetryPolicy configures RetryCtx
+type RetryPolicy struct {
+	// Backoff decides the wait between attempts, nil means retrying immediately
+	Backoff Backoff
+	// MaxAttempts limits number of calls in total, 0 means no limit
+	MaxAttempts int
+	// MaxElapsed limits total time spent; retry that would start after it passes is not attempted. 0 means no limit
+	MaxElapsed time.Duration
+	// Retryable decides whether error is worth retrying, nil means every error is.
+	// Errors marked via Permanent are never retried regardless of it
+	Retryable func(err error) bool
+	// OnRetry is called after failed attempt that will be retried, with attempt number, its error and delay before next one.
+	// Intended for logging and metrics
+	OnRetry func(attempt int, err error, next time.Duration)
+	// Clock used for measuring elapsed time and waiting, nil means RealClock
+	Clock Clock
+	// MaxErrors limits number of most recent attempt errors returned, apart from the first one,
+	// to keep memory bounded on long retries. Omitted ones are replaced by a note with their count. 0 means keeping all
+	MaxErrors int
+}
+
+// RetryCtx runs function until it succeeds, waiting between attempts as decided by policy's Backoff.
+// It stops when MaxAttempts or MaxElapsed budget is exhausted or when context is cancelled,
+// returning last result and every attempt error (plus ctx.Err() if cancelled) joined via errors.Join,
+// see RetryPolicy.MaxErrors to limit them.
+// With neither limit set it retries until context is cancelled.
+// Non-retryable error (see Permanent and RetryPolicy.Retryable) stops it immediately.
+// Current attempt number is available to the function via RetryAttempt(ctx)
+func RetryCtx[T any](ctx context.Context, policy RetryPolicy, f func(ctx context.Context) (T, error)) (out T, err error) {
+	clock := policy.Clock
+	if clock == nil {
+		clock = RealClock{}
+	}
+	start := clock.Now()
+	errs := retryErrors{max: policy.MaxErrors}
+	var delay time.Duration
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			return out, errs.join(ctx.Err())
+		}
+		out, err = f(context.WithValue(ctx, retryAttemptKey{}, attempt))
+		if err == nil {
+			return out, nil
+		}
+		errs.add(fmt.Errorf("attempt %d: %w", attempt, err))
+		if IsPermanent(err) || (policy.Retryable != nil && !policy.Retryable(err)) {
+			return out, errs.join()
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return out, errs.join()
+		}
+		if policy.Backoff != nil {
+			delay = policy.Backoff.Delay(attempt, delay)
+		}
+		if policy.MaxElapsed > 0 && clock.Now().Sub(start)+delay > policy.MaxElapsed {
+			return out, errs.join()
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+		select {
+		case <-ctx.Done():
+			return out, errs.join(ctx.Err())
+		case <-clock.After(delay):
+		}
+	}
+}
+
+// retryErrors keeps the first and the last `max` errors (all if `max` is 0), counting the ones dropped in between
+type retryErrors struct {
+	max     int
+	first   error
+	last    []error
+	omitted int
+}
+
+func (e *retryErrors) add(err error) {
+	if e.first == nil {
+		e.first = err
+		return
+	}
+	if e.max > 0 && len(e.last) == e.max {
+		copy(e.last, e.last[1:])
+		e.last = e.last[:e.max-1]
+		e.omitted++
+	}
+	e.last = append(e.last, err)
+}
+
+func (e *retryErrors) join(extra ...error) error {
+	errs := []error{e.first}
+	if e.omitted > 0 {
+		errs = append(errs, fmt.Errorf("%d more attempt errors omitted", e.omitted))
+	}
+	errs = append(errs, e.last...)
+	return errors.Join(append(errs, extra...)...)
+}
+
 // Try every function till one returns without error
 func Try[T any](f ...func() (T, error)) (out T, err error) {
 	for _, ff := range f {
@@ -1,7 +1,9 @@
 package goneric
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"sync"
 	"testing"
@@ -129,3 +131,236 @@ func TestTry(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestRetryCtx(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		a1 := fa{}
+		start := time.Now()
+		out, err := RetryCtx(context.Background(), RetryPolicy{
+			Backoff:     BackoffExponential{Initial: time.Second},
+			MaxAttempts: 5,
+		}, func(ctx context.Context) (int, error) { return a1.OkAfter(3) })
+		assert.NoError(t, err)
+		assert.Equal(t, 3, out)
+		// 1s + 2s wait
+		assert.Equal(t, time.Second*3, time.Since(start))
+	})
+}
+
+func TestRetryCtxMaxAttempts(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		a1 := fa{}
+		out, err := RetryCtx(context.Background(), RetryPolicy{
+			Backoff:     BackoffConstant{Interval: time.Second},
+			MaxAttempts: 3,
+		}, func(ctx context.Context) (int, error) { return a1.OkAfter(10) })
+		assert.Error(t, err)
+		assert.Equal(t, 3, out)
+		// every attempt's error is kept
+		assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+		assert.ErrorContains(t, err, "attempt 1: fail")
+		assert.ErrorContains(t, err, "attempt 3: fail")
+	})
+}
+
+func TestRetryCtxMaxElapsed(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		calls := 0
+		start := time.Now()
+		_, err := RetryCtx(context.Background(), RetryPolicy{
+			Backoff:    BackoffConstant{Interval: time.Second * 3},
+			MaxElapsed: time.Second * 10,
+		}, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errors.New("fail")
+		})
+		assert.Error(t, err)
+		// attempts at 0s, 3s, 6s, 9s, next one would be past the budget
+		assert.Equal(t, 4, calls)
+		assert.Equal(t, time.Second*9, time.Since(start))
+	})
+}
+
+func TestRetryCtxCancel(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		calls := 0
+		start := time.Now()
+		_, err := RetryCtx(ctx, RetryPolicy{
+			Backoff: BackoffConstant{Interval: time.Second * 2},
+		}, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errors.New("fail")
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "fail")
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, time.Second*5, time.Since(start), "should stop waiting on cancel")
+
+		_, err = RetryCtx(ctx, RetryPolicy{}, func(ctx context.Context) (int, error) {
+			t.Fatal("should not be called with cancelled context")
+			return 0, nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestRetryPermanent(t *testing.T) {
+	errBad := errors.New("bad request")
+	calls := 0
+	_, err := Retry(5, func() (int, error) {
+		calls++
+		return 0, Permanent(errBad)
+	})
+	assert.ErrorIs(t, err, errBad)
+	assert.True(t, IsPermanent(err))
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, Permanent(nil))
+	assert.False(t, IsPermanent(errBad))
+	assert.True(t, IsPermanent(fmt.Errorf("wrapped: %w", Permanent(errBad))))
+
+	calls = 0
+	_, err = RetryAfter(time.Millisecond, time.Millisecond, time.Second, func() (int, error) {
+		calls++
+		return 0, Permanent(errBad)
+	})
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	_, err = RetryCtx(context.Background(), RetryPolicy{MaxAttempts: 5}, func(ctx context.Context) (int, error) {
+		calls++
+		if calls == 2 {
+			return 0, Permanent(errBad)
+		}
+		return 0, errors.New("transient")
+	})
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRetryCtxRetryable(t *testing.T) {
+	errBad := errors.New("bad request")
+	calls := 0
+	_, err := RetryCtx(context.Background(), RetryPolicy{
+		MaxAttempts: 5,
+		Retryable:   func(err error) bool { return !errors.Is(err, errBad) },
+	}, func(ctx context.Context) (int, error) {
+		calls++
+		if calls == 3 {
+			return 0, errBad
+		}
+		return 0, errors.New("transient")
+	})
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryCtxHooks(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var attempts []int
+		var retries []string
+		out, err := RetryCtx(context.Background(), RetryPolicy{
+			Backoff:     BackoffLinear{Initial: time.Second, Step: time.Second},
+			MaxAttempts: 5,
+			OnRetry: func(attempt int, err error, next time.Duration) {
+				retries = append(retries, fmt.Sprintf("%d %s %s", attempt, err, next))
+			},
+		}, func(ctx context.Context) (int, error) {
+			attempt := RetryAttempt(ctx)
+			attempts = append(attempts, attempt)
+			if attempt < 3 {
+				return 0, errors.New("fail")
+			}
+			return attempt * 10, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 30, out)
+		assert.Equal(t, []int{1, 2, 3}, attempts)
+		assert.Equal(t, []string{"1 fail 1s", "2 fail 2s"}, retries)
+		assert.Equal(t, 0, RetryAttempt(context.Background()))
+
+		// not called when there is no retry after failure
+		hooks := 0
+		_, err = RetryCtx(context.Background(), RetryPolicy{
+			MaxAttempts: 2,
+			OnRetry:     func(attempt int, err error, next time.Duration) { hooks++ },
+		}, func(ctx context.Context) (int, error) { return 0, errors.New("fail") })
+		assert.Error(t, err)
+		assert.Equal(t, 1, hooks)
+	})
+}
+
+func TestRetryAfterClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	var calls []time.Duration
+	result := make(chan error)
+	go func() {
+		_, err := RetryAfterClock(clock, time.Second, time.Second*2, time.Second*5, func() (int, error) {
+			calls = append(calls, clock.Now().Sub(start))
+			return 0, errors.New("fail")
+		})
+		result <- err
+	}()
+	// interval grows 1s -> 1.5s -> 2s (max), last call happens once next interval would pass the timeout
+	for _, d := range []time.Duration{time.Second, time.Millisecond * 1500, time.Second * 2, time.Second * 2} {
+		clock.BlockUntil(1)
+		clock.Advance(d)
+	}
+	assert.Error(t, <-result)
+	assert.Equal(t, []time.Duration{0, time.Second, time.Millisecond * 2500, time.Millisecond * 4500, time.Millisecond * 6500}, calls)
+}
+
+func TestRetryCtxClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	var calls []time.Duration
+	result := make(chan error)
+	go func() {
+		_, err := RetryCtx(context.Background(), RetryPolicy{
+			Backoff:    BackoffExponential{Initial: time.Second},
+			MaxElapsed: time.Second * 10,
+			Clock:      clock,
+		}, func(ctx context.Context) (int, error) {
+			calls = append(calls, clock.Now().Sub(start))
+			return 0, errors.New("fail")
+		})
+		result <- err
+	}()
+	for _, d := range []time.Duration{time.Second, time.Second * 2, time.Second * 4} {
+		clock.BlockUntil(1)
+		clock.Advance(d)
+	}
+	assert.Error(t, <-result)
+	// next 8s wait would pass the 10s budget
+	assert.Equal(t, []time.Duration{0, time.Second, time.Second * 3, time.Second * 7}, calls)
+}
+
+func TestRetryCtxErrorsBounded(t *testing.T) {
+	errFirst := errors.New("first")
+	calls := 0
+	f := func(ctx context.Context) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errFirst
+		}
+		return 0, fmt.Errorf("fail %d", calls)
+	}
+	_, err := RetryCtx(context.Background(), RetryPolicy{MaxAttempts: 100}, f)
+	assert.Equal(t, 100, calls)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 100, "every attempt error should be kept by default")
+
+	calls = 0
+	_, err = RetryCtx(context.Background(), RetryPolicy{MaxAttempts: 100, MaxErrors: 10}, f)
+	assert.Equal(t, 100, calls)
+	assert.ErrorIs(t, err, errFirst)
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	// first, note about omitted ones, and the last 10
+	assert.Len(t, errs, 12)
+	assert.ErrorContains(t, err, "89 more attempt errors omitted")
+	assert.ErrorContains(t, err, "attempt 91: fail 91")
+	assert.ErrorContains(t, err, "attempt 100: fail 100")
+	assert.NotContains(t, err.Error(), "attempt 90:")
+}
@@ -0,0 +1,194 @@
+package goneric
+
+import "iter"
+
+// All "*Seq" functions are lazy equivalents of their namesakes, operating on range-over-func iterators.
+// Nothing is computed until the iterator is ranged over, and stopping early stops the upstream too.
+
+// MapSeq maps iterator using provided function
+func MapSeq[T1, T2 any](mapFunc func(v T1) T2, seq iter.Seq[T1]) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for v := range seq {
+			if !yield(mapFunc(v)) {
+				return
+			}
+		}
+	}
+}
+
+// MapSeqErr maps iterator using provided function, yielding result with error.
+// Iteration stops after first error
+func MapSeqErr[T1, T2 any](mapFunc func(v T1) (T2, error), seq iter.Seq[T1]) iter.Seq2[T2, error] {
+	return func(yield func(T2, error) bool) {
+		for v := range seq {
+			out, err := mapFunc(v)
+			if !yield(out, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// MapSeqSkip maps iterator using provided function, allowing to skip entries by returning true
+func MapSeqSkip[T1, T2 any](mapFunc func(v T1) (T2, bool), seq iter.Seq[T1]) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for v := range seq {
+			r, skip := mapFunc(v)
+			if skip {
+				continue
+			}
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// MapMapSeq runs every key/value pair thru function that returns new key and value. Types can vary between in and out.
+func MapMapSeq[K1, K2, V1, V2 any](mapFunc func(k K1, v V1) (K2, V2), seq iter.Seq2[K1, V1]) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range seq {
+			if !yield(mapFunc(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// MapSliceKeySeq returns iterator over keys of a map
+func MapSliceKeySeq[K comparable, V any](in map[K]V) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range in {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// MapSliceValueSeq returns iterator over values of a map
+func MapSliceValueSeq[K comparable, V any](in map[K]V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range in {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MapToSliceSeq returns iterator over map elements converted via specified function
+func MapToSliceSeq[K comparable, V any, V2 any](f func(k K, v V) V2, in map[K]V) iter.Seq[V2] {
+	return func(yield func(V2) bool) {
+		for k, v := range in {
+			if !yield(f(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq yields only elements for which function returned true
+func FilterSeq[T any](filterFunc func(v T) (accept bool), seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if filterFunc(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FilterMapSeq yields only key/value pairs for which function returned true
+func FilterMapSeq[K, V any](filterFunc func(k K, v V) (accept bool), seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if filterFunc(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// SliceDedupeSeq yields only first occurrence of every element
+// Memory use grows with number of unique elements
+func SliceDedupeSeq[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		presence := make(map[T]bool, 0)
+		for v := range seq {
+			if _, ok := presence[v]; ok {
+				continue
+			}
+			presence[v] = true
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SliceDedupeFuncSeq yields only first occurrence of every element, with function to convert the value to comparable
+// Memory use grows with number of unique elements
+func SliceDedupeFuncSeq[T any, C comparable](seq iter.Seq[T], convert func(T) C) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		presence := make(map[C]bool, 0)
+		for v := range seq {
+			c := convert(v)
+			if _, ok := presence[c]; ok {
+				continue
+			}
+			presence[c] = true
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ChanSeq returns iterator reading from channel until it is closed
+// Stopping iteration early leaves the rest of messages in the channel
+func ChanSeq[T any](inCh chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range inCh {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqToChan feeds iterator to channel in background
+// setting optional argument to true will close the channel after finishing
+func SeqToChan[T any](seq iter.Seq[T], out chan T, closeOutputChan ...bool) {
+	go func() {
+		for v := range seq {
+			out <- v
+		}
+		if len(closeOutputChan) > 0 && closeOutputChan[0] {
+			close(out)
+		}
+	}()
+}
+
+// GenSeq returns infinite iterator fed from function results
+func GenSeq[T any](genFunc func() T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			if !yield(genFunc()) {
+				return
+			}
+		}
+	}
+}
+
+// GenSeqN returns iterator that runs function n times
+// Function gets id of element starting from 0.
+func GenSeqN[T any](genFunc func(idx int) T, count int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < count; i++ {
+			if !yield(genFunc(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package goneric
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMapSeq(t *testing.T) {
+	out := slices.Collect(MapSeq(strconv.Itoa, slices.Values([]int{1, 2, 3})))
+	assert.Equal(t, []string{"1", "2", "3"}, out)
+	// stops early
+	calls := 0
+	for range MapSeq(func(i int) int { calls++; return i }, GenSeq(func() int { return 1 })) {
+		if calls >= 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestMapSeqErr(t *testing.T) {
+	out := []float64{}
+	var err error
+	for v, e := range MapSeqErr(func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	}, slices.Values([]string{"1", "2.2", "cat", "5"})) {
+		if e != nil {
+			err = e
+			break
+		}
+		out = append(out, v)
+	}
+	assert.Equal(t, []float64{1, 2.2}, out)
+	assert.Error(t, err)
+
+	count := 0
+	for range MapSeqErr(func(s string) (int, error) { return 0, errors.New("fail") }, slices.Values([]string{"a", "b"})) {
+		count++
+	}
+	assert.Equal(t, 1, count, "should stop after first error")
+}
+
+func TestMapSeqSkip(t *testing.T) {
+	out := slices.Collect(MapSeqSkip(func(s string) (int, bool) {
+		i, err := strconv.Atoi(s)
+		return i, err != nil
+	}, slices.Values([]string{"1", "cat", "3"})))
+	assert.Equal(t, []int{1, 3}, out)
+}
+
+func TestMapMapSeq(t *testing.T) {
+	out := maps.Collect(MapMapSeq(func(k string, v int) (int, string) {
+		return v, k
+	}, maps.All(map[string]int{"a": 1, "b": 2})))
+	assert.Equal(t, map[int]string{1: "a", 2: "b"}, out)
+}
+
+func TestMapSliceKeySeq(t *testing.T) {
+	in := map[string]int{"a": 1, "b": 2}
+	assert.True(t, CompareSliceSet([]string{"a", "b"}, slices.Collect(MapSliceKeySeq(in))))
+	assert.True(t, CompareSliceSet([]int{1, 2}, slices.Collect(MapSliceValueSeq(in))))
+	assert.True(t, CompareSliceSet([]string{"a1", "b2"}, slices.Collect(MapToSliceSeq(func(k string, v int) string {
+		return k + strconv.Itoa(v)
+	}, in))))
+}
+
+func TestFilterSeq(t *testing.T) {
+	out := slices.Collect(FilterSeq(func(v int) bool { return v%2 == 0 }, GenSeqN(func(idx int) int { return idx }, 10)))
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, out)
+
+	m := maps.Collect(FilterMapSeq(func(k string, v int) bool { return v > 1 }, maps.All(map[string]int{"a": 1, "b": 2, "c": 3})))
+	assert.Equal(t, map[string]int{"b": 2, "c": 3}, m)
+}
+
+func TestSliceDedupeSeq(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(SliceDedupeSeq(slices.Values([]int{1, 2, 1, 3, 2}))))
+	assert.Equal(t,
+		[]string{"a", "bb"},
+		slices.Collect(SliceDedupeFuncSeq(slices.Values([]string{"a", "bb", "c", "dd"}), func(s string) int { return len(s) })),
+	)
+}
+
+func TestChanSeq(t *testing.T) {
+	ch := GenChanN(func(idx int) int { return idx }, 5, true)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(ChanSeq(ch)))
+
+	out := make(chan int, 1)
+	SeqToChan(GenSeqN(func(idx int) int { return idx * 2 }, 3), out, true)
+	assert.Equal(t, []int{0, 2, 4}, ChanToSlice(out))
+}
+
+func ExampleMapSeq() {
+	in := []string{"1", "2", "cat", "2", "3", "5"}
+	// nothing is allocated between the steps
+	out := MapSeq(
+		func(i int) string { return fmt.Sprintf("0x%02x", i) },
+		SliceDedupeSeq(
+			MapSeqSkip(func(s string) (int, bool) {
+				i, err := strconv.Atoi(s)
+				return i, err != nil
+			}, slices.Values(in)),
+		),
+	)
+	for v := range out {
+		fmt.Print(v, " ")
+	}
+	// Output: 0x01 0x02 0x03 0x05
+}
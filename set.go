@@ -0,0 +1,160 @@
+package goneric
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Set is a set of comparable values, using the same `map[T]bool{true}` convention as SliceMapSet,
+// so maps returned by SliceMapSet/SliceMapSetFunc can be converted directly via `Set[T](m)`
+// Presence of the key is what counts, value should always be true.
+// Operations returning a set always return a new one, inputs are unchanged
+type Set[T comparable] map[T]bool
+
+// NewSet creates set from variadic values
+func NewSet[T comparable](values ...T) Set[T] {
+	return SliceToSet(values)
+}
+
+// SliceToSet creates set from slice elements
+func SliceToSet[T comparable](slice []T) Set[T] {
+	return Set[T](SliceMapSet(slice))
+}
+
+// SliceToSetFunc creates set from slice elements converted to comparable via function
+func SliceToSetFunc[T any, M comparable](mapFunc func(T) M, slice []T) Set[M] {
+	return Set[M](SliceMapSetFunc(mapFunc, slice))
+}
+
+// MapKeyToSet creates set from map keys
+func MapKeyToSet[K comparable, V any](in map[K]V) Set[K] {
+	s := make(Set[K], len(in))
+	for k := range in {
+		s[k] = true
+	}
+	return s
+}
+
+// Add adds values to the set
+func (s Set[T]) Add(values ...T) {
+	for _, v := range values {
+		s[v] = true
+	}
+}
+
+// Remove removes values from the set
+func (s Set[T]) Remove(values ...T) {
+	for _, v := range values {
+		delete(s, v)
+	}
+}
+
+// Has checks whether value is in the set
+func (s Set[T]) Has(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Len returns number of elements in the set
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Union returns set with elements that are in either of sets
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	out := make(Set[T], len(s)+len(other))
+	for k := range s {
+		out[k] = true
+	}
+	for k := range other {
+		out[k] = true
+	}
+	return out
+}
+
+// Intersect returns set with elements that are in both sets
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	// iterate over the smaller one
+	small, big := s, other
+	if len(small) > len(big) {
+		small, big = big, small
+	}
+	out := make(Set[T], len(small))
+	for k := range small {
+		if _, ok := big[k]; ok {
+			out[k] = true
+		}
+	}
+	return out
+}
+
+// Difference returns set with elements that are in this set but not in the other one
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	out := make(Set[T], len(s))
+	for k := range s {
+		if _, ok := other[k]; !ok {
+			out[k] = true
+		}
+	}
+	return out
+}
+
+// SymmetricDifference returns set with elements that are in only one of the sets
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	out := s.Difference(other)
+	for k := range other {
+		if _, ok := s[k]; !ok {
+			out[k] = true
+		}
+	}
+	return out
+}
+
+// IsSubset checks whether every element of this set is in the other one
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for k := range s {
+		if _, ok := other[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset checks whether every element of the other set is in this one
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal checks whether both sets have same elements
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// All returns iterator over set elements, order is random
+func (s Set[T]) All() iter.Seq[T] {
+	return MapSliceKeySeq(s)
+}
+
+// Slice returns set elements as slice, order is random
+func (s Set[T]) Slice() []T {
+	return MapSliceKey(s)
+}
+
+// SortedFunc returns set elements as slice sorted via comparison function
+// that returns negative, zero or positive number like cmp.Compare
+func (s Set[T]) SortedFunc(cmpFunc func(a, b T) int) []T {
+	out := MapSliceKey(s)
+	slices.SortFunc(out, cmpFunc)
+	return out
+}
+
+// SetSorted returns set elements as sorted slice
+func SetSorted[T cmp.Ordered](s Set[T]) []T {
+	out := MapSliceKey(s)
+	slices.Sort(out)
+	return out
+}
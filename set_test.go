@@ -0,0 +1,63 @@
+package goneric
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSetConstructors(t *testing.T) {
+	assert.Equal(t, Set[int]{1: true, 2: true}, NewSet(1, 2, 2))
+	assert.Equal(t, Set[int]{}, NewSet[int]())
+	assert.Equal(t, Set[string]{"a": true, "b": true}, SliceToSet([]string{"a", "b", "a"}))
+	assert.Equal(t, Set[int]{1: true, 3: true}, SliceToSetFunc(func(s string) int { return len(s) }, []string{"a", "bbb", "c"}))
+	assert.Equal(t, Set[string]{"a": true, "b": true}, MapKeyToSet(map[string]int{"a": 1, "b": 2}))
+	// raw SliceMapSet output converts directly
+	assert.True(t, Set[int](SliceMapSet([]int{1, 2})).Equal(NewSet(2, 1)))
+}
+
+func TestSetBasic(t *testing.T) {
+	s := NewSet(1, 2)
+	s.Add(3, 4)
+	assert.True(t, s.Has(3))
+	assert.False(t, s.Has(5))
+	s.Remove(1, 5)
+	assert.False(t, s.Has(1))
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, CompareSliceSet([]int{2, 3, 4}, s.Slice()))
+	assert.True(t, CompareSliceSet([]int{2, 3, 4}, slices.Collect(s.All())))
+	assert.Equal(t, []int{2, 3, 4}, SetSorted(s))
+	assert.Equal(t, []int{4, 3, 2}, s.SortedFunc(func(a, b int) int { return b - a }))
+}
+
+func TestSetOperations(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+	assert.Equal(t, NewSet(1, 2, 3, 4, 5), a.Union(b))
+	assert.Equal(t, NewSet(3, 4), a.Intersect(b))
+	assert.Equal(t, NewSet(3, 4), b.Intersect(a))
+	assert.Equal(t, NewSet(1, 2), a.Difference(b))
+	assert.Equal(t, NewSet(5), b.Difference(a))
+	assert.Equal(t, NewSet(1, 2, 5), a.SymmetricDifference(b))
+	assert.Equal(t, NewSet(1, 2, 3, 4), a, "input should be unchanged")
+
+	assert.True(t, NewSet(3, 4).IsSubset(a))
+	assert.False(t, b.IsSubset(a))
+	assert.False(t, a.IsSubset(NewSet(1)))
+	assert.True(t, NewSet[int]().IsSubset(a))
+	assert.True(t, a.IsSuperset(NewSet(1, 2)))
+	assert.False(t, a.IsSuperset(b))
+	assert.True(t, a.Equal(NewSet(4, 3, 2, 1)))
+	assert.False(t, a.Equal(b))
+}
+
+func ExampleSet() {
+	admins := SliceToSet([]string{"alice", "bob"})
+	online := NewSet("bob", "carol", "dave")
+	fmt.Println(strings.Join(SetSorted(admins.Intersect(online)), ","))
+	fmt.Println(strings.Join(SetSorted(online.Difference(admins)), ","))
+	// Output: bob
+	// carol,dave
+}
@@ -25,7 +25,7 @@ func CompareSliceSet[T comparable](v1 []T, v2 []T) bool {
 
 // SliceMap turn slice into a map via extracting key from it using helper function
 // and setting the map value to that slice
-// `[]Struct{} -> map[func(Struct)]Struct`
+// `[]T -> map[func(T)K][]T`
 func SliceMap[T any, M comparable](f func(T) M, a []T) map[M]T {
 	n := make(map[M]T, len(a))
 	for _, e := range a {
@@ -36,7 +36,7 @@ func SliceMap[T any, M comparable](f func(T) M, a []T) map[M]T {
 
 // SliceMapSkip works like `SliceMap` but
 // allows slice->map function to skip elements via returning true to second argument
-// `[]Struct{} -> map[func(Struct)]Struct`
+// `[]T -> map[func(T)K][]T`
 func SliceMapSkip[T any, Z comparable](comparable func(T) (comparable Z, skip bool), slice []T) (m map[Z]T) {
 	m = make(map[Z]T, len(slice))
 	for _, e := range slice {
@@ -233,3 +233,83 @@ func LastOrDefault[T any](slice []T, def T) (out T) {
 		return def
 	}
 }
+
+// SliceGroupBy groups slice elements into map by key extracted via function
+// Unlike SliceMap every element is kept, in the same order as in the input
+// `[]T -> map[func(T)K][]T`
+func SliceGroupBy[T any, K comparable](keyFunc func(T) K, slice []T) map[K][]T {
+	out := make(map[K][]T)
+	for _, v := range slice {
+		k := keyFunc(v)
+		out[k] = append(out[k], v)
+	}
+	return out
+}
+
+// SliceGroupByFunc groups slice elements into map using function to extract both key and value
+// `[]Any -> map[comparable K][]V`
+func SliceGroupByFunc[T any, K comparable, V any](mapFunc func(T) (K, V), slice []T) map[K][]V {
+	out := make(map[K][]V)
+	for _, e := range slice {
+		k, v := mapFunc(e)
+		out[k] = append(out[k], v)
+	}
+	return out
+}
+
+// SlicePartition splits slice into elements for which function returned true and the rest
+// Order is kept
+// `[]T -> (match []T, rest []T)`
+func SlicePartition[T any](filterFunc func(T) bool, slice []T) (match []T, rest []T) {
+	// we want to return empty slice, not nil slice
+	match = []T{}
+	rest = []T{}
+	for _, v := range slice {
+		if filterFunc(v) {
+			match = append(match, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return match, rest
+}
+
+// SliceChunk splits slice into chunks of `size` elements, last one can be shorter
+// Chunks share backing array with input but are capped so appending to them won't overwrite the next one
+// `[]T -> [][]T`
+func SliceChunk[T any](slice []T, size int) (out [][]T) {
+	if size < 1 {
+		panic("RTFM")
+	}
+	out = make([][]T, 0, (len(slice)+size-1)/size)
+	for i := 0; i < len(slice); i += size {
+		end := Min(i+size, len(slice))
+		out = append(out, slice[i:end:end])
+	}
+	return out
+}
+
+// SliceWindow returns sliding windows of `size` elements, starting every `step` elements
+// Only full windows are returned, so input shorter than `size` returns no windows
+// Windows share backing array with input but are capped so appending to them won't overwrite the input
+// `[]T -> [][]T`
+func SliceWindow[T any](slice []T, size int, step int) (out [][]T) {
+	if size < 1 || step < 1 {
+		panic("RTFM")
+	}
+	out = make([][]T, 0)
+	for i := 0; i+size <= len(slice); i += step {
+		out = append(out, slice[i:i+size:i+size])
+	}
+	return out
+}
+
+// SliceCountBy counts slice elements by key extracted via function
+// `[]T -> map[func(T)Comparable]int`
+func SliceCountBy[T any, K comparable](keyFunc func(T) K, slice []T) map[K]int {
+	out := make(map[K]int)
+	for _, v := range slice {
+		out[keyFunc(v)]++
+	}
+	return out
+}
@@ -298,3 +298,56 @@ func TestLastOrDefault(t *testing.T) {
 	assert.Equal(t, 2, LastOrDefault([]int{4, 3, 2}, 7))
 	assert.Equal(t, 7, LastOrDefault([]int{}, 7))
 }
+
+func TestSliceGroupBy(t *testing.T) {
+	words := []string{"a", "bb", "c", "dd", "eee"}
+	assert.Equal(t, map[int][]string{
+		1: {"a", "c"},
+		2: {"bb", "dd"},
+		3: {"eee"},
+	}, SliceGroupBy(func(s string) int { return len(s) }, words))
+	assert.Equal(t, map[int][]string{}, SliceGroupBy(func(s string) int { return len(s) }, []string{}))
+
+	assert.Equal(t, map[bool][]int{
+		true:  {1, 3},
+		false: {2},
+	}, SliceGroupByFunc(func(s string) (bool, int) {
+		i, _ := strconv.Atoi(s)
+		return i%2 == 1, i
+	}, []string{"1", "2", "3"}))
+}
+
+func TestSlicePartition(t *testing.T) {
+	match, rest := SlicePartition(func(i int) bool { return i > 2 }, []int{1, 5, 2, 4, 3})
+	assert.Equal(t, []int{5, 4, 3}, match)
+	assert.Equal(t, []int{1, 2}, rest)
+	match, rest = SlicePartition(func(i int) bool { return true }, []int{})
+	assert.NotNil(t, match)
+	assert.NotNil(t, rest)
+}
+
+func TestSliceChunk(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, SliceChunk(in, 2))
+	assert.Equal(t, [][]int{{1, 2, 3, 4, 5}}, SliceChunk(in, 5))
+	assert.Equal(t, [][]int{{1, 2, 3, 4, 5}}, SliceChunk(in, 10))
+	assert.Equal(t, [][]int{}, SliceChunk([]int{}, 3))
+	chunks := SliceChunk(in, 2)
+	_ = append(chunks[0], 99)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, in, "append to chunk should not overwrite input")
+	assert.Panics(t, func() { SliceChunk(in, 0) })
+}
+
+func TestSliceWindow(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, SliceWindow(in, 3, 1))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, SliceWindow(in, 2, 2))
+	assert.Equal(t, [][]int{}, SliceWindow(in, 6, 1))
+	assert.Panics(t, func() { SliceWindow(in, 0, 1) })
+	assert.Panics(t, func() { SliceWindow(in, 1, 0) })
+}
+
+func TestSliceCountBy(t *testing.T) {
+	assert.Equal(t, map[int]int{1: 2, 2: 1}, SliceCountBy(func(s string) int { return len(s) }, []string{"a", "bb", "c"}))
+	assert.Equal(t, map[int]int{}, SliceCountBy(func(s string) int { return len(s) }, []string{}))
+}
@@ -0,0 +1,247 @@
+package goneric
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// QuantileMethod selects how quantile is computed when it falls between two elements
+type QuantileMethod int
+
+const (
+	// QuantileLinear interpolates linearly between two closest elements.
+	// Same as numpy default, R type 7 and spreadsheet PERCENTILE.INC
+	QuantileLinear QuantileMethod = iota
+	// QuantileLower picks the lower of two closest elements
+	QuantileLower
+	// QuantileHigher picks the higher of two closest elements
+	QuantileHigher
+	// QuantileNearest picks the closest element, rounding half away from zero
+	QuantileNearest
+	// QuantileMidpoint averages two closest elements
+	QuantileMidpoint
+)
+
+// Quantile calculates q-th quantile (0 <= q <= 1) using selected method.
+// Input is unchanged, sorting is done on a copy.
+// Interpolated result is converted back to type so for integers it will be rounded down,
+// use QuantileF64 to avoid that
+// will panic on empty or q outside of 0-1 range
+func Quantile[T Number](q float64, method QuantileMethod, n ...T) T {
+	c := make([]T, len(n))
+	copy(c, n)
+	return QuantileInplace(q, method, c...)
+}
+
+// QuantileF64 calculates q-th quantile (0 <= q <= 1) using selected method, returning float64
+// Input is unchanged, sorting is done on a copy.
+// will panic on empty or q outside of 0-1 range
+func QuantileF64[T Number](q float64, method QuantileMethod, n ...T) float64 {
+	c := make([]T, len(n))
+	copy(c, n)
+	return QuantileF64Inplace(q, method, c...)
+}
+
+// QuantileInplace calculates q-th quantile (0 <= q <= 1) using selected method, sorting the input in the process.
+// Passing a slice as `s...` will reorder it, as variadic call does not copy
+// will panic on empty or q outside of 0-1 range
+func QuantileInplace[T Number](q float64, method QuantileMethod, n ...T) T {
+	return T(QuantileF64Inplace(q, method, n...))
+}
+
+// QuantileF64Inplace calculates q-th quantile (0 <= q <= 1) using selected method, returning float64,
+// sorting the input in the process.
+// Passing a slice as `s...` will reorder it, as variadic call does not copy
+// will panic on empty or q outside of 0-1 range
+func QuantileF64Inplace[T Number](q float64, method QuantileMethod, n ...T) float64 {
+	if len(n) == 0 || q < 0 || q > 1 {
+		panic("RTFM")
+	}
+	slices.Sort(n)
+	pos := q * float64(len(n)-1)
+	lower := n[int(math.Floor(pos))]
+	upper := n[int(math.Ceil(pos))]
+	switch method {
+	case QuantileLower:
+		return float64(lower)
+	case QuantileHigher:
+		return float64(upper)
+	case QuantileNearest:
+		return float64(n[int(math.Round(pos))])
+	case QuantileMidpoint:
+		return (float64(lower) + float64(upper)) / 2
+	default:
+		frac := pos - math.Floor(pos)
+		return float64(lower) + frac*(float64(upper)-float64(lower))
+	}
+}
+
+// Percentile calculates p-th percentile (0 <= p <= 100), see Quantile
+func Percentile[T Number](p float64, method QuantileMethod, n ...T) T {
+	return Quantile(p/100, method, n...)
+}
+
+// PercentileF64 calculates p-th percentile (0 <= p <= 100) returning float64, see QuantileF64
+func PercentileF64[T Number](p float64, method QuantileMethod, n ...T) float64 {
+	return QuantileF64(p/100, method, n...)
+}
+
+// PercentileInplace calculates p-th percentile (0 <= p <= 100) sorting the input in the process, see QuantileInplace
+func PercentileInplace[T Number](p float64, method QuantileMethod, n ...T) T {
+	return QuantileInplace(p/100, method, n...)
+}
+
+// PercentileF64Inplace calculates p-th percentile (0 <= p <= 100) returning float64
+// and sorting the input in the process, see QuantileF64Inplace
+func PercentileF64Inplace[T Number](p float64, method QuantileMethod, n ...T) float64 {
+	return QuantileF64Inplace(p/100, method, n...)
+}
+
+// Variance calculates population variance. Calculation is done in float64, result is converted back to type
+// will panic on empty
+func Variance[T Number](n ...T) T {
+	return T(VarianceF64(n...))
+}
+
+// VarianceF64 calculates population variance with float64 accumulator
+// will panic on empty
+func VarianceF64[T Number](n ...T) float64 {
+	if len(n) == 0 {
+		panic("RTFM")
+	}
+	return sumSquaredDiff(n...) / float64(len(n))
+}
+
+// VarianceSample calculates sample variance (with Bessel's correction).
+// Calculation is done in float64, result is converted back to type
+// will panic with less than 2 elements
+func VarianceSample[T Number](n ...T) T {
+	return T(VarianceSampleF64(n...))
+}
+
+// VarianceSampleF64 calculates sample variance (with Bessel's correction) with float64 accumulator
+// will panic with less than 2 elements
+func VarianceSampleF64[T Number](n ...T) float64 {
+	if len(n) < 2 {
+		panic("RTFM")
+	}
+	return sumSquaredDiff(n...) / float64(len(n)-1)
+}
+
+// StdDev calculates population standard deviation. Calculation is done in float64, result is converted back to type
+// will panic on empty
+func StdDev[T Number](n ...T) T {
+	return T(StdDevF64(n...))
+}
+
+// StdDevF64 calculates population standard deviation with float64 accumulator
+// will panic on empty
+func StdDevF64[T Number](n ...T) float64 {
+	return math.Sqrt(VarianceF64(n...))
+}
+
+// StdDevSample calculates sample standard deviation (with Bessel's correction).
+// Calculation is done in float64, result is converted back to type
+// will panic with less than 2 elements
+func StdDevSample[T Number](n ...T) T {
+	return T(StdDevSampleF64(n...))
+}
+
+// StdDevSampleF64 calculates sample standard deviation (with Bessel's correction) with float64 accumulator
+// will panic with less than 2 elements
+func StdDevSampleF64[T Number](n ...T) float64 {
+	return math.Sqrt(VarianceSampleF64(n...))
+}
+
+// sumSquaredDiff returns sum of squared differences from the mean
+func sumSquaredDiff[T Number](n ...T) (sum float64) {
+	mean := AvgF64F64(n...)
+	for _, v := range n {
+		d := float64(v) - mean
+		sum += d * d
+	}
+	return sum
+}
+
+// Mode returns most common value. If there is more than one, the smallest one is returned
+// returns zero value on empty input
+func Mode[T Number](n ...T) (mode T) {
+	counts := make(map[T]int, len(n))
+	best := 0
+	for _, v := range n {
+		counts[v]++
+		c := counts[v]
+		if c > best || (c == best && v < mode) {
+			best = c
+			mode = v
+		}
+	}
+	return mode
+}
+
+// MinMax returns smallest and biggest number in single pass
+// will panic on empty
+func MinMax[T Number](n ...T) (min T, max T) {
+	min, max = n[0], n[0]
+	for _, v := range n[1:] {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return min, max
+}
+
+// Histogram counts values into buckets with given upper bounds, which have to be sorted ascending.
+// Bucket `i` counts values `bounds[i-1] < v <= bounds[i]`, and the extra last bucket counts values above the highest bound,
+// so there is always `len(bounds)+1` buckets
+func Histogram[T Number](bounds []T, n ...T) (counts []int) {
+	counts = make([]int, len(bounds)+1)
+	for _, v := range n {
+		counts[sort.Search(len(bounds), func(i int) bool { return v <= bounds[i] })]++
+	}
+	return counts
+}
+
+// HistogramBucketsLinear generates `count` bucket bounds for Histogram, starting from `start` and spaced by `width`
+func HistogramBucketsLinear[T Number](start T, width T, count int) (bounds []T) {
+	bounds = make([]T, count)
+	for i := range bounds {
+		bounds[i] = start + width*T(i)
+	}
+	return bounds
+}
+
+// HistogramBucketsExponential generates `count` bucket bounds for Histogram, starting from `start` and each multiplied by `factor`
+func HistogramBucketsExponential[T Number](start T, factor float64, count int) (bounds []T) {
+	bounds = make([]T, count)
+	b := float64(start)
+	for i := range bounds {
+		bounds[i] = T(b)
+		b = b * factor
+	}
+	return bounds
+}
+
+// WeightedAvg calculates weighted average. Calculation is done in float64, result is converted back to type
+// will panic if slices have different length
+func WeightedAvg[T Number](values []T, weights []T) T {
+	return T(WeightedAvgF64(values, weights))
+}
+
+// WeightedAvgF64 calculates weighted average with float64 accumulator
+// will panic if slices have different length
+func WeightedAvgF64[T Number](values []T, weights []T) float64 {
+	if len(values) != len(weights) {
+		panic("RTFM")
+	}
+	var sum, weightSum float64
+	for i, v := range values {
+		sum += float64(v) * float64(weights[i])
+		weightSum += float64(weights[i])
+	}
+	return sum / weightSum
+}
@@ -0,0 +1,124 @@
+package goneric
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	n := []int{5, 1, 4, 2, 3}
+	assert.Equal(t, 3, Quantile(0.5, QuantileLinear, n...))
+	assert.Equal(t, 1, Quantile(0, QuantileLinear, n...))
+	assert.Equal(t, 5, Quantile(1, QuantileLinear, n...))
+	assert.Equal(t, []int{5, 1, 4, 2, 3}, n, "input should be unchanged")
+
+	f := []float64{10, 20, 30, 40}
+	// position 0.4*3 = 1.2, between 20 and 30
+	assert.InDelta(t, 22.0, QuantileF64(0.4, QuantileLinear, f...), 1e-9)
+	assert.Equal(t, 20.0, QuantileF64(0.4, QuantileLower, f...))
+	assert.Equal(t, 30.0, QuantileF64(0.4, QuantileHigher, f...))
+	assert.Equal(t, 20.0, QuantileF64(0.4, QuantileNearest, f...))
+	assert.Equal(t, 30.0, QuantileF64(0.6, QuantileNearest, f...))
+	assert.Equal(t, 25.0, QuantileF64(0.4, QuantileMidpoint, f...))
+
+	// ints round down, F64 variant doesn't
+	assert.Equal(t, 2, Quantile(0.5, QuantileLinear, 1, 2, 3, 4))
+	assert.Equal(t, 2.5, QuantileF64(0.5, QuantileLinear, 1, 2, 3, 4))
+	// same as median
+	assert.Equal(t, MedianF64(1, 2, 3, 4, 9), QuantileF64(0.5, QuantileLinear, 1, 2, 3, 4, 9))
+
+	assert.Panics(t, func() { Quantile(0.5, QuantileLinear, []int{}...) })
+	assert.Panics(t, func() { Quantile(1.5, QuantileLinear, 1, 2) })
+	assert.Panics(t, func() { Quantile(-0.1, QuantileLinear, 1, 2) })
+}
+
+func TestQuantileInplace(t *testing.T) {
+	n := []int{5, 1, 4, 2, 3}
+	assert.Equal(t, 2, QuantileInplace(0.25, QuantileLinear, n...))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, n)
+	n = []int{4, 1, 3, 2}
+	assert.Equal(t, 1.75, QuantileF64Inplace(0.25, QuantileLinear, n...))
+	assert.Equal(t, []int{1, 2, 3, 4}, n)
+}
+
+func TestPercentile(t *testing.T) {
+	n := GenSlice(101, func(idx int) int { return 100 - idx })
+	assert.Equal(t, 99, Percentile(99, QuantileLinear, n...))
+	assert.Equal(t, 99.5, PercentileF64(99.5, QuantileLinear, n...))
+	assert.Equal(t, 100, n[0], "input should be unchanged")
+	assert.Equal(t, 90, PercentileInplace(90, QuantileLinear, n...))
+	assert.Equal(t, 0, n[0], "input should be sorted")
+	assert.Equal(t, 50.0, PercentileF64Inplace(50, QuantileLinear, n...))
+}
+
+func TestVariance(t *testing.T) {
+	n := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	assert.Equal(t, 4.0, VarianceF64(n...))
+	assert.Equal(t, 4, Variance(n...))
+	assert.InDelta(t, 32.0/7, VarianceSampleF64(n...), 1e-9)
+	assert.Equal(t, 4, VarianceSample(n...))
+	assert.Equal(t, 2.0, StdDevF64(n...))
+	assert.Equal(t, 2, StdDev(n...))
+	assert.InDelta(t, math.Sqrt(32.0/7), StdDevSampleF64(n...), 1e-9)
+	assert.Equal(t, 2, StdDevSample(n...))
+	assert.Equal(t, 0.0, VarianceF64(3.0, 3.0, 3.0))
+	// no overflow on small ints
+	assert.Equal(t, 0.0, VarianceF64([]uint8{200, 200, 200}...))
+	assert.Equal(t, 0, Variance(3))
+	assert.Panics(t, func() { Variance[int]() })
+	assert.Panics(t, func() { VarianceF64[float64]() })
+	assert.Panics(t, func() { StdDev[int]() })
+	assert.Panics(t, func() { VarianceSample(3) })
+	assert.Panics(t, func() { StdDevSampleF64(3.0) })
+}
+
+func TestMode(t *testing.T) {
+	assert.Equal(t, 3, Mode(1, 3, 2, 3, 1, 3))
+	assert.Equal(t, 1, Mode(3, 1, 2, 3, 1), "ties should return smallest")
+	assert.Equal(t, -5, Mode(-5, 2, 2, -5))
+	assert.Equal(t, 0, Mode[int]())
+	assert.Equal(t, 7.5, Mode(7.5))
+}
+
+func TestMinMax(t *testing.T) {
+	min, max := MinMax(3, 1, 4, 1, 5, 9, 2, 6)
+	assert.Equal(t, 1, min)
+	assert.Equal(t, 9, max)
+	min, max = MinMax(7)
+	assert.Equal(t, 7, min)
+	assert.Equal(t, 7, max)
+	assert.Panics(t, func() { MinMax([]int{}...) })
+}
+
+func TestHistogram(t *testing.T) {
+	assert.Equal(t, []int{2, 1, 2, 1}, Histogram([]int{10, 20, 30}, 1, 10, 15, 21, 30, 99))
+	assert.Equal(t, []int{0}, Histogram([]int{}))
+	assert.Equal(t, []int{3}, Histogram([]int{}, 1, 2, 3))
+	assert.Equal(t, []int{10, 20, 30}, HistogramBucketsLinear(10, 10, 3))
+	assert.Equal(t, []float64{0.5, 1, 1.5}, HistogramBucketsLinear(0.5, 0.5, 3))
+	assert.Equal(t, []int{1, 2, 4, 8}, HistogramBucketsExponential(1, 2, 4))
+	assert.Equal(t, []float64{0.001, 0.01, 0.1}, MapSlice(func(f float64) float64 {
+		return math.Round(f*1000) / 1000
+	}, HistogramBucketsExponential(0.001, 10, 3)))
+}
+
+func TestWeightedAvg(t *testing.T) {
+	assert.Equal(t, 2.25, WeightedAvgF64([]int{1, 2, 3}, []int{1, 1, 2}))
+	assert.Equal(t, 2, WeightedAvg([]int{1, 2, 3}, []int{1, 1, 2}))
+	assert.Equal(t, 3.0, WeightedAvgF64([]float64{1, 3}, []float64{0, 1}))
+	assert.Panics(t, func() { WeightedAvg([]int{1}, []int{1, 2}) })
+}
+
+func ExampleHistogram() {
+	latencyMs := []float64{3, 7, 12, 18, 45, 51, 120, 980}
+	bounds := HistogramBucketsExponential(10.0, 4, 3)
+	fmt.Println(bounds, Histogram(bounds, latencyMs...))
+	fmt.Printf("p50: %.1f p90: %.1f\n",
+		PercentileF64(50, QuantileLinear, latencyMs...),
+		PercentileF64(90, QuantileLinear, latencyMs...),
+	)
+	// Output: [10 40 160] [2 2 3 1]
+	// p50: 31.5 p90: 378.0
+}
@@ -0,0 +1,230 @@
+package goneric
+
+import (
+	"iter"
+)
+
+// Stream is a lazy pipeline of operations over a sequence of elements.
+// Stages are not run until one of the sinks (Slice, Chan, Each, StreamToMap, StreamReduce...) is called,
+// and no intermediate slices are allocated between them.
+// Stages that change element type are functions (StreamMap, StreamBatch...) as Go methods can't have type parameters
+type Stream[T any] struct {
+	seq iter.Seq[T]
+}
+
+// StreamFromSeq creates stream from iterator
+func StreamFromSeq[T any](seq iter.Seq[T]) Stream[T] {
+	return Stream[T]{seq: seq}
+}
+
+// StreamFromSlice creates stream from slice elements
+func StreamFromSlice[T any](in []T) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		for _, v := range in {
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// StreamFromMap creates stream of map's key/value pairs. Order is random, as with ranging over map
+func StreamFromMap[K comparable, V any](in map[K]V) Stream[KeyValue[K, V]] {
+	return Stream[KeyValue[K, V]]{seq: func(yield func(KeyValue[K, V]) bool) {
+		for k, v := range in {
+			if !yield(KeyValue[K, V]{K: k, V: v}) {
+				return
+			}
+		}
+	}}
+}
+
+// StreamFromChan creates stream reading from channel until it is closed
+func StreamFromChan[T any](in chan T) Stream[T] {
+	return Stream[T]{seq: ChanSeq(in)}
+}
+
+// StreamFromGen creates infinite stream fed from function results. Use Take() to limit it
+func StreamFromGen[T any](genFunc func() T) Stream[T] {
+	return Stream[T]{seq: GenSeq(genFunc)}
+}
+
+// Filter passes only elements for which function returned true
+func (s Stream[T]) Filter(filterFunc func(T) bool) Stream[T] {
+	return Stream[T]{seq: FilterSeq(filterFunc, s.seq)}
+}
+
+// Take passes at most n first elements then stops the upstream
+func (s Stream[T]) Take(n int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range s.seq {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}}
+}
+
+// Skip drops n first elements
+func (s Stream[T]) Skip(n int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		i := 0
+		for v := range s.seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Seq returns stream as iterator
+func (s Stream[T]) Seq() iter.Seq[T] {
+	return s.seq
+}
+
+// Slice runs the stream and returns the result as slice
+func (s Stream[T]) Slice() []T {
+	out := make([]T, 0)
+	for v := range s.seq {
+		out = append(out, v)
+	}
+	return out
+}
+
+// Chan runs the stream in background, sending results to returned channel then closing it.
+// Caller should consume the whole output channel or else it will leak goroutines
+func (s Stream[T]) Chan() chan T {
+	out := make(chan T, 1)
+	SeqToChan(s.seq, out, true)
+	return out
+}
+
+// Each runs the stream, calling function on every element
+func (s Stream[T]) Each(f func(T)) {
+	for v := range s.seq {
+		f(v)
+	}
+}
+
+// StreamMap maps stream elements via function
+func StreamMap[T1, T2 any](mapFunc func(T1) T2, s Stream[T1]) Stream[T2] {
+	return Stream[T2]{seq: MapSeq(mapFunc, s.seq)}
+}
+
+// StreamParallelMap maps stream elements via function in parallel, up to `concurrency` goroutines.
+// Like with WorkerPool the order of elements is not kept.
+// Upstream is only pulled when there is a free worker, so stopping the stream early (via Take or breaking the loop)
+// stops the upstream without consuming anything more from it
+func StreamParallelMap[T1, T2 any](mapFunc func(T1) T2, concurrency int, s Stream[T1]) Stream[T2] {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	return Stream[T2]{seq: func(yield func(T2) bool) {
+		next, stop := iter.Pull(s.seq)
+		defer stop()
+		in := make(chan T1)
+		defer close(in)
+		// room for every element in flight, so workers never block on it and exit once input is closed
+		out := make(chan T2, concurrency)
+		for i := 0; i < concurrency; i++ {
+			go func() {
+				for v := range in {
+					out <- mapFunc(v)
+				}
+			}()
+		}
+		inFlight := 0
+		upstreamDone := false
+		for {
+			// pass on finished results first, so slow upstream doesn't delay them
+			select {
+			case v := <-out:
+				inFlight--
+				if !yield(v) {
+					return
+				}
+				continue
+			default:
+			}
+			if !upstreamDone && inFlight < concurrency {
+				v, ok := next()
+				if ok {
+					in <- v
+					inFlight++
+					continue
+				}
+				upstreamDone = true
+			}
+			if inFlight == 0 {
+				return
+			}
+			inFlight--
+			if !yield(<-out) {
+				return
+			}
+		}
+	}}
+}
+
+// StreamDedupe removes duplicates from stream. Memory use grows with number of unique elements
+func StreamDedupe[T comparable](s Stream[T]) Stream[T] {
+	return Stream[T]{seq: SliceDedupeSeq(s.seq)}
+}
+
+// StreamDedupeFunc removes duplicates from stream with function to convert the value to comparable
+func StreamDedupeFunc[T any, C comparable](s Stream[T], convert func(T) C) Stream[T] {
+	return Stream[T]{seq: SliceDedupeFuncSeq(s.seq, convert)}
+}
+
+// StreamBatch groups stream elements into slices of up to `size` elements, last one can be shorter
+func StreamBatch[T any](size int, s Stream[T]) Stream[[]T] {
+	if size < 1 {
+		panic("RTFM")
+	}
+	return Stream[[]T]{seq: func(yield func([]T) bool) {
+		batch := make([]T, 0, size)
+		for v := range s.seq {
+			batch = append(batch, v)
+			if len(batch) >= size {
+				if !yield(batch) {
+					return
+				}
+				batch = make([]T, 0, size)
+			}
+		}
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}}
+}
+
+// StreamToMap runs the stream and returns map with key and value extracted via function
+func StreamToMap[T any, K comparable, V any](mapFunc func(T) (K, V), s Stream[T]) map[K]V {
+	out := make(map[K]V)
+	for v := range s.seq {
+		k, v := mapFunc(v)
+		out[k] = v
+	}
+	return out
+}
+
+// StreamReduce runs the stream, passing each element with accumulator to function and returning final accumulator
+func StreamReduce[T, A any](reduceFunc func(acc A, v T) A, init A, s Stream[T]) A {
+	acc := init
+	for v := range s.seq {
+		acc = reduceFunc(acc, v)
+	}
+	return acc
+}
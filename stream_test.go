@@ -0,0 +1,111 @@
+package goneric
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"iter"
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+func TestStreamSources(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, StreamFromSlice([]int{1, 2, 3}).Slice())
+	assert.Equal(t, []int{}, StreamFromSlice([]int{}).Slice())
+	assert.Equal(t, []int{0, 1, 2}, StreamFromChan(GenChanN(func(idx int) int { return idx }, 3, true)).Slice())
+	assert.Equal(t, []int{0, 1}, StreamFromSeq(GenSeqN(func(idx int) int { return idx }, 2)).Slice())
+	c := ctr{}
+	assert.Equal(t, []int{1, 2, 3, 4}, StreamFromGen(c.Counter).Take(4).Slice())
+	kv := StreamFromMap(map[string]int{"a": 1, "b": 2}).Slice()
+	assert.True(t, CompareSliceSet([]KeyValue[string, int]{{K: "a", V: 1}, {K: "b", V: 2}}, kv))
+}
+
+func TestStreamStages(t *testing.T) {
+	s := StreamFromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	assert.Equal(t, []int{2, 4, 6, 8, 10}, s.Filter(func(i int) bool { return i%2 == 0 }).Slice())
+	assert.Equal(t, []int{1, 2, 3}, s.Take(3).Slice())
+	assert.Equal(t, []int{}, s.Take(0).Slice())
+	assert.Equal(t, []int{8, 9, 10}, s.Skip(7).Slice())
+	assert.Equal(t, []int{}, s.Skip(20).Slice())
+	assert.Equal(t, []string{"1", "2"}, StreamMap(strconv.Itoa, s).Take(2).Slice())
+	assert.Equal(t, [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10}}, StreamBatch(4, s).Slice())
+	assert.Equal(t, [][]int{{1, 2, 3, 4, 5}, {6, 7, 8, 9, 10}}, StreamBatch(5, s).Slice())
+	assert.Panics(t, func() { StreamBatch(0, s) })
+	d := StreamFromSlice([]int{1, 2, 1, 3, 2})
+	assert.Equal(t, []int{1, 2, 3}, StreamDedupe(d).Slice())
+	assert.Equal(t, []int{1, 2}, StreamDedupeFunc(d, func(i int) bool { return i%2 == 0 }).Slice())
+}
+
+func TestStreamSinks(t *testing.T) {
+	s := StreamFromSlice([]string{"a", "bb", "ccc"})
+	assert.Equal(t, map[string]int{"a": 1, "bb": 2, "ccc": 3}, StreamToMap(func(v string) (string, int) {
+		return v, len(v)
+	}, s))
+	assert.Equal(t, 6, StreamReduce(func(acc int, v string) int { return acc + len(v) }, 0, s))
+	assert.Equal(t, []string{"a", "bb", "ccc"}, ChanToSlice(s.Chan()))
+	assert.Equal(t, []string{"a", "bb", "ccc"}, slices.Collect(s.Seq()))
+	out := []string{}
+	s.Each(func(v string) { out = append(out, v) })
+	assert.Equal(t, []string{"a", "bb", "ccc"}, out)
+}
+
+func TestStreamParallelMap(t *testing.T) {
+	s := StreamFromSlice(GenSlice(100, func(idx int) int { return idx }))
+	out := StreamParallelMap(func(i int) int { return i * 2 }, 4, s).Slice()
+	sort.Ints(out)
+	assert.Equal(t, GenSlice(100, func(idx int) int { return idx * 2 }), out)
+
+	// stopping early stops the infinite source
+	out = StreamParallelMap(func(i int) int { return i }, 4, StreamFromGen(func() int { return 1 })).Take(10).Slice()
+	assert.Len(t, out, 10)
+	assert.Panics(t, func() { StreamParallelMap(func(i int) int { return i }, 0, s) })
+
+	// upstream is not drained after stopping, and nothing is left running with idle open channel
+	synctest.Test(t, func(t *testing.T) {
+		ch := make(chan int, 10)
+		for i := range 10 {
+			ch <- i
+		}
+		out := StreamParallelMap(func(i int) int { return i }, 2, StreamFromChan(ch)).Take(3).Slice()
+		assert.Len(t, out, 3)
+		synctest.Wait()
+		left := len(ch)
+		assert.GreaterOrEqual(t, left, 10-3-1, "only elements in flight should be consumed")
+		time.Sleep(time.Second)
+		assert.Equal(t, left, len(ch), "nothing should be consumed after stream stopped")
+	})
+}
+
+func TestStreamLazy(t *testing.T) {
+	calls := 0
+	var src iter.Seq[int] = func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			calls++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	s := StreamMap(func(i int) int { return i * 10 }, StreamFromSeq(src).Filter(func(i int) bool { return i%2 == 1 }))
+	assert.Equal(t, 0, calls, "nothing should run before sink")
+	assert.Equal(t, []int{10, 30, 50}, s.Take(3).Slice())
+	assert.Equal(t, 6, calls)
+}
+
+func ExampleStream() {
+	in := []string{"3", "1", "cat", "3", "4", "1", "5", "9", "2", "6"}
+	out := StreamMap(
+		func(i int) string { return fmt.Sprintf("<%d>", i) },
+		StreamDedupe(
+			StreamMap(
+				func(s string) int { return Must(strconv.Atoi(s)) },
+				StreamFromSlice(in).Filter(func(s string) bool { return s != "cat" }),
+			),
+		).Skip(1).Take(4),
+	).Slice()
+	fmt.Println(out)
+	// Output: [<1> <4> <5> <9>]
+}
@@ -1,11 +1,47 @@
 package goneric
 
+import "fmt"
+
 type ErrSkip struct{}
 
 func (v ErrSkip) Error() string {
 	return "fake error marking entries to skip"
 }
 
+// ErrOverflow is returned by checked math functions when result does not fit in the type
+type ErrOverflow struct{}
+
+func (v ErrOverflow) Error() string {
+	return "numeric overflow"
+}
+
+// ErrEmpty is returned by functions that can't produce a result from empty input
+type ErrEmpty struct{}
+
+func (v ErrEmpty) Error() string {
+	return "empty input"
+}
+
+// ErrPoolClosed is returned when submitting job to a Pool that was closed
+type ErrPoolClosed struct{}
+
+func (v ErrPoolClosed) Error() string {
+	return "pool is closed"
+}
+
+// PermanentError marks error as not worth retrying, see Permanent
+type PermanentError struct {
+	Err error
+}
+
+func (e PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e PermanentError) Unwrap() error {
+	return e.Err
+}
+
 type Number interface {
 	~int | ~int64 | ~int32 | ~int16 | ~int8 |
 		~uint64 | ~uint32 | ~uint16 | ~uint8 |
@@ -29,3 +65,25 @@ type Response[DataT, ReturnT any] struct {
 	ReturnCh chan ReturnT
 	Data     DataT
 }
+
+// PanicError is returned in place of a panic recovered from the passed function
+type PanicError struct {
+	// Value passed to panic()
+	Value any
+	// Stack of the goroutine at the time of panic
+	Stack []byte
+	// Key identifies the input that caused the panic: index for slices, key for maps and input value for channels
+	Key any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic on input [%v]: %v", e.Key, e.Value)
+}
+
+// Unwrap returns panic value if it was an error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
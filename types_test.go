@@ -1,6 +1,7 @@
 package goneric
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -8,3 +9,17 @@ import (
 func TestTypes(t *testing.T) {
 	assert.NotEmpty(t, ErrSkip{}.Error())
 }
+
+func TestPanicError(t *testing.T) {
+	inner := errors.New("inner")
+	e := &PanicError{Value: inner, Key: 3}
+	assert.Contains(t, e.Error(), "inner")
+	assert.Contains(t, e.Error(), "3")
+	assert.ErrorIs(t, e, inner)
+	assert.Nil(t, (&PanicError{Value: "str"}).Unwrap())
+}
+
+func TestErrTypes(t *testing.T) {
+	assert.NotEmpty(t, ErrOverflow{}.Error())
+	assert.NotEmpty(t, ErrEmpty{}.Error())
+}
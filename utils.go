@@ -1,5 +1,7 @@
 package goneric
 
+import "runtime/debug"
+
 // Must takes any value and error, returns the value and panics if error happens.
 func Must[T any](in T, err error) (out T) {
 	if err != nil {
@@ -17,3 +19,17 @@ func IgnoreErr[T any](in T, err error) (out T) {
 	}
 	return in
 }
+
+// unpanic runs function, turning panic into *PanicError tagged with the key of input
+func unpanic[T1, T2 any](key any, f func(T1) T2, in T1) (out T2, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{
+				Value: r,
+				Stack: debug.Stack(),
+				Key:   key,
+			}
+		}
+	}()
+	return f(in), nil
+}
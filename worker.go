@@ -1,7 +1,10 @@
 package goneric
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"time"
 )
 
 // WorkerPool spawns `concurrency` goroutines eating from input channel and sending it to output channel
@@ -105,3 +108,501 @@ func WorkerPoolAsync[T1, T2 any](worker func(T1) T2, concurrency int) (async fun
 			<-finish
 		}
 }
+
+// WorkerPoolAsyncPriority works like WorkerPoolAsync but queued jobs are run in order of priority, highest first,
+// and in order of queueing for equal priority. Queue is unbounded so queueing never blocks.
+// With `agingInterval` above zero waiting job's priority grows by 1 every `agingInterval`,
+// so low priority jobs are not starved by constant stream of higher priority ones.
+// Stop finishes queued jobs and waits for workers; jobs queued after stop have their result channel closed without value
+// will panic if concurrency is less than 1
+func WorkerPoolAsyncPriority[T1, T2 any](
+	worker func(T1) T2,
+	concurrency int,
+	agingInterval time.Duration,
+) (async func(job T1, priority int) chan T2, stop func()) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	type item struct {
+		job Response[T1, T2]
+		// priority adjusted for aging; waiting job's effective priority is `priority + waited/agingInterval`,
+		// and comparing that between two jobs doesn't depend on current time, so it can be fixed at queueing
+		key float64
+		seq uint64
+	}
+	queue := NewHeap(func(a, b item) bool {
+		if a.key != b.key {
+			return a.key > b.key
+		}
+		return a.seq < b.seq
+	})
+	lock := sync.Mutex{}
+	cond := sync.NewCond(&lock)
+	stopped := false
+	var seq uint64
+	start := time.Now()
+
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				lock.Lock()
+				for queue.Len() == 0 && !stopped {
+					cond.Wait()
+				}
+				it, ok := queue.Pop()
+				lock.Unlock()
+				if !ok {
+					return
+				}
+				it.job.ReturnCh <- worker(it.job.Data)
+			}
+		}()
+	}
+	stopOnce := sync.Once{}
+	return func(job T1, priority int) chan T2 {
+			ch := make(chan T2, 1)
+			lock.Lock()
+			defer lock.Unlock()
+			if stopped {
+				close(ch)
+				return ch
+			}
+			key := float64(priority)
+			if agingInterval > 0 {
+				key -= float64(time.Since(start)) / float64(agingInterval)
+			}
+			queue.Push(item{
+				job: Response[T1, T2]{ReturnCh: ch, Data: job},
+				key: key,
+				seq: seq,
+			})
+			seq++
+			cond.Signal()
+			return ch
+		}, func() {
+			stopOnce.Do(func() {
+				lock.Lock()
+				stopped = true
+				cond.Broadcast()
+				lock.Unlock()
+			})
+			wg.Wait()
+		}
+}
+
+// WorkerPoolCtx works like WorkerPool but passes context to the worker and can be cancelled.
+// On cancellation workers stop pulling from input channel, pending sends to output are abandoned
+// and ctx.Err() is returned; nil is returned if input channel was closed and processed.
+// optionally setting last option to true will make it close output channel after workers finish
+func WorkerPoolCtx[T1, T2 any](
+	ctx context.Context,
+	input chan T1,
+	output chan T2,
+	worker func(context.Context, T1) T2,
+	concurrency int,
+	closeOutputChan ...bool,
+) error {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			workerCtxLoop(ctx, input, output, worker)
+		}()
+	}
+	wg.Wait()
+	if len(closeOutputChan) > 0 && closeOutputChan[0] {
+		close(output)
+	}
+	return ctx.Err()
+}
+
+// WorkerPoolCtxBackground works like WorkerPoolBackground but passes context to the worker and can be cancelled.
+// On cancellation workers stop and, if requested, output channel is closed. Check ctx.Err() to see whether processing was cut short.
+// optionally setting last option to true will make it close output channel
+func WorkerPoolCtxBackground[T1, T2 any](
+	ctx context.Context,
+	input chan T1,
+	worker func(context.Context, T1) T2,
+	concurrency int,
+	closeOutputChan ...bool,
+) (output chan T2) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	output = make(chan T2, concurrency/2+1)
+	go func() {
+		WorkerPoolCtx(ctx, input, output, worker, concurrency, closeOutputChan...)
+	}()
+	return output
+}
+
+// WorkerPoolCtxFinisher runs WorkerPoolCtx in the background and
+// returns channel that returns the result of the run (nil or ctx.Err()) then closes when workers finish
+// Output channel is closed when workers finish
+func WorkerPoolCtxFinisher[T1, T2 any](
+	ctx context.Context,
+	input chan T1,
+	output chan T2,
+	worker func(context.Context, T1) T2,
+	concurrency int,
+) chan error {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	finisher := make(chan error, 1)
+	go func() {
+		finisher <- WorkerPoolCtx(ctx, input, output, worker, concurrency, true)
+		close(finisher)
+	}()
+	return finisher
+}
+
+// WorkerPoolCtxDrain runs function per input without returning anything. Goroutines close on channel close or context cancellation.
+// returns finish channel that returns nil (or ctx.Err() if cancelled) after goroutines finish
+func WorkerPoolCtxDrain[T1 any](ctx context.Context, worker func(context.Context, T1), concurrency int, input chan T1) (finish chan error) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	finish = make(chan error, 1)
+	go func() {
+		wg := sync.WaitGroup{}
+		wg.Add(concurrency)
+		for i := 0; i < concurrency; i++ {
+			go func() {
+				defer wg.Done()
+				for {
+					if ctx.Err() != nil {
+						return
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case w, ok := <-input:
+						if !ok {
+							return
+						}
+						worker(ctx, w)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		finish <- ctx.Err()
+	}()
+	return finish
+}
+
+// WorkerPoolCtxAsync returns a function that adds new job to queue and returns a channel with result, and function to stop worker.
+// After context is cancelled queueing returns ctx.Err() and result channels of jobs that didn't get to run are closed without value,
+// so check for `v, ok := <-ch`. Stop returns nil or ctx.Err() if the pool was cancelled
+func WorkerPoolCtxAsync[T1, T2 any](
+	ctx context.Context,
+	worker func(context.Context, T1) T2,
+	concurrency int,
+) (async func(T1) (chan T2, error), stop func() error) {
+	inCh := make(chan Response[T1, T2], concurrency/2+1)
+	finish := WorkerPoolCtxDrain(ctx, func(ctx context.Context, in Response[T1, T2]) {
+		in.ReturnCh <- worker(ctx, in.Data)
+	}, concurrency, inCh)
+	done := make(chan error, 1)
+	go func() {
+		err := <-finish
+		// workers are gone, so whatever is left in the queue will never run
+		for in := range inCh {
+			close(in.ReturnCh)
+		}
+		done <- err
+	}()
+
+	return func(in T1) (chan T2, error) {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			ch := make(chan T2, 1)
+			select {
+			case inCh <- Response[T1, T2]{
+				ReturnCh: ch,
+				Data:     in,
+			}:
+				return ch, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}, func() error {
+			close(inCh)
+			return <-done
+		}
+}
+
+// WorkerPoolErr spawns `concurrency` goroutines eating from input channel and sending results to output channel
+// First error returned by worker cancels the context passed to the other workers, stops the pool and is returned.
+// Results of failed calls are not sent to output.
+// Cancelling parent context stops the pool and returns ctx.Err()
+// optionally setting last option to true will make it close output channel after workers finish
+func WorkerPoolErr[T1, T2 any](
+	ctx context.Context,
+	input chan T1,
+	output chan T2,
+	worker func(context.Context, T1) (T2, error),
+	concurrency int,
+	closeOutputChan ...bool,
+) error {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			workerCtxLoopErr(ctx, input, output, worker, func(err error) bool {
+				cancel(err)
+				return false
+			})
+		}()
+	}
+	wg.Wait()
+	if len(closeOutputChan) > 0 && closeOutputChan[0] {
+		close(output)
+	}
+	// either first worker error or parent's cancellation reason
+	return context.Cause(ctx)
+}
+
+// WorkerPoolErrAll works like WorkerPoolErr but does not stop on error,
+// instead it processes the whole input and returns all errors joined via errors.Join.
+// Cancelling parent context stops the pool and adds ctx.Err() to returned errors
+// optionally setting last option to true will make it close output channel after workers finish
+func WorkerPoolErrAll[T1, T2 any](
+	ctx context.Context,
+	input chan T1,
+	output chan T2,
+	worker func(context.Context, T1) (T2, error),
+	concurrency int,
+	closeOutputChan ...bool,
+) error {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	var errs []error
+	errLock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			workerCtxLoopErr(ctx, input, output, worker, func(err error) bool {
+				errLock.Lock()
+				errs = append(errs, err)
+				errLock.Unlock()
+				return true
+			})
+		}()
+	}
+	wg.Wait()
+	if len(closeOutputChan) > 0 && closeOutputChan[0] {
+		close(output)
+	}
+	return errors.Join(append(errs, ctx.Err())...)
+}
+
+// WorkerPoolUnpanic works like WorkerPool but recovers panics in worker.
+// Input that caused the panic is skipped and the panic is returned as *PanicError
+// with input value as a Key. All of them are joined via errors.Join
+// optionally setting last option to true will make it close output channel after input is processed and closed
+func WorkerPoolUnpanic[T1, T2 any](input chan T1, output chan T2, worker func(T1) T2, concurrency int, closeOutputChan ...bool) error {
+	return WorkerPoolErrAll(context.Background(), input, output, func(_ context.Context, v T1) (T2, error) {
+		return unpanic(v, worker, v)
+	}, concurrency, closeOutputChan...)
+}
+
+// WorkerPoolBackgroundUnpanic works like WorkerPoolBackground but recovers panics in worker.
+// Input that caused the panic is skipped and the panic is sent to error channel as *PanicError with input value as a Key.
+// Both channels need to be read or else it will stall. Error channel is closed after workers finish
+// optionally setting last option to true will make it close output channel
+func WorkerPoolBackgroundUnpanic[T1, T2 any](
+	input chan T1,
+	worker func(T1) T2,
+	concurrency int,
+	closeOutputChan ...bool,
+) (output chan T2, errCh chan error) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	output = make(chan T2, concurrency/2+1)
+	errCh = make(chan error, 1)
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for w := range input {
+				out, err := unpanic(w, worker, w)
+				if err != nil {
+					errCh <- err
+				} else {
+					output <- out
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(errCh)
+		if len(closeOutputChan) > 0 && closeOutputChan[0] {
+			close(output)
+		}
+	}()
+	return output, errCh
+}
+
+// WorkerPoolFinisherUnpanic works like WorkerPoolFinisher but recovers panics in worker.
+// Input that caused the panic is skipped. Returned channel gets panics as *PanicError with input value as a Key,
+// joined via errors.Join (or nil if there were none), then closes when workers finish
+// will panic if concurrency is less than 1
+func WorkerPoolFinisherUnpanic[T1, T2 any](input chan T1, output chan T2, worker func(T1) T2, concurrency int) chan error {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	finisher := make(chan error, 1)
+	go func() {
+		finisher <- WorkerPoolUnpanic(input, output, worker, concurrency, true)
+		close(finisher)
+	}()
+	return finisher
+}
+
+// WorkerPoolAsyncUnpanic works like WorkerPoolAsync but recovers panics in worker.
+// Result channel of job that panicked is closed without value, so check for `v, ok := <-ch`.
+// Stop returns panics as *PanicError with job input as a Key, joined via errors.Join (or nil if there were none)
+// will panic if concurrency is less than 1
+func WorkerPoolAsyncUnpanic[T1, T2 any](worker func(T1) T2, concurrency int) (async func(T1) chan T2, stop func() error) {
+	if concurrency < 1 {
+		panic("RTFM")
+	}
+	var errs []error
+	errLock := sync.Mutex{}
+	inCh := make(chan Response[T1, T2], concurrency/2+1)
+	finish := WorkerPoolDrain(func(in Response[T1, T2]) {
+		out, err := unpanic(in.Data, worker, in.Data)
+		if err != nil {
+			errLock.Lock()
+			errs = append(errs, err)
+			errLock.Unlock()
+			close(in.ReturnCh)
+			return
+		}
+		in.ReturnCh <- out
+	}, concurrency, inCh)
+
+	return func(in T1) (out chan T2) {
+			ch := make(chan T2, 1)
+			inCh <- Response[T1, T2]{
+				ReturnCh: ch,
+				Data:     in,
+			}
+			return ch
+		}, func() error {
+			close(inCh)
+			<-finish
+			errLock.Lock()
+			defer errLock.Unlock()
+			return errors.Join(errs...)
+		}
+}
+
+// WorkerPoolDrainUnpanic works like WorkerPoolDrain but recovers panics in worker.
+// returns finish channel that returns panics joined via errors.Join (or nil if there were none) after goroutines finish
+func WorkerPoolDrainUnpanic[T1 any](worker func(T1), concurrency int, input chan T1) (finish chan error) {
+	finish = make(chan error, 1)
+	go func() {
+		var errs []error
+		errLock := sync.Mutex{}
+		wg := sync.WaitGroup{}
+		wg.Add(concurrency)
+		for i := 0; i < concurrency; i++ {
+			go func() {
+				defer wg.Done()
+				for w := range input {
+					_, err := unpanic(w, func(v T1) struct{} { worker(v); return struct{}{} }, w)
+					if err != nil {
+						errLock.Lock()
+						errs = append(errs, err)
+						errLock.Unlock()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		finish <- errors.Join(errs...)
+	}()
+	return finish
+}
+
+// workerCtxLoop feeds input to worker until input is closed or context is cancelled
+func workerCtxLoop[T1, T2 any](ctx context.Context, input chan T1, output chan T2, worker func(context.Context, T1) T2) {
+	for {
+		// select picks at random so check first, to not pull more work after cancellation
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case w, ok := <-input:
+			if !ok {
+				return
+			}
+			select {
+			case output <- worker(ctx, w):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// workerCtxLoopErr feeds input to worker until input is closed or context is cancelled
+// errors are passed to onErr which decides whether to carry on
+func workerCtxLoopErr[T1, T2 any](
+	ctx context.Context,
+	input chan T1,
+	output chan T2,
+	worker func(context.Context, T1) (T2, error),
+	onErr func(error) (carryOn bool),
+) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case w, ok := <-input:
+			if !ok {
+				return
+			}
+			out, err := worker(ctx, w)
+			if err != nil {
+				if !onErr(err) {
+					return
+				}
+				continue
+			}
+			select {
+			case output <- out:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
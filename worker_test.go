@@ -1,11 +1,14 @@
 package goneric
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"sort"
 	"strconv"
 	"testing"
+	"testing/synctest"
 	"time"
 )
 
@@ -189,3 +192,351 @@ func ExampleWorkerPoolBackground() {
 	fmt.Printf("%v", sliceOut)
 	// output: [ |>1  |>2  |>3]
 }
+
+func TestWorkerPoolAsyncPriority(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		release := make(chan bool)
+		var order []string
+		async, stop := WorkerPoolAsyncPriority(func(v string) string {
+			if v == "blocker" {
+				<-release
+			}
+			order = append(order, v)
+			return v + "!"
+		}, 1, 0)
+		first := async("blocker", 0)
+		// wait for the worker to pick up the blocker so the rest are queued
+		synctest.Wait()
+		results := map[string]chan string{}
+		for _, j := range []struct {
+			name     string
+			priority int
+		}{{"bulk1", 0}, {"bulk2", 0}, {"interactive", 10}, {"normal", 5}} {
+			results[j.name] = async(j.name, j.priority)
+		}
+		close(release)
+		assert.Equal(t, "blocker!", <-first)
+		for name, ch := range results {
+			assert.Equal(t, name+"!", <-ch)
+		}
+		stop()
+		assert.Equal(t, []string{"blocker", "interactive", "normal", "bulk1", "bulk2"}, order)
+		_, ok := <-async("late", 1)
+		assert.False(t, ok, "job queued after stop should not run")
+		stop()
+	})
+	assert.Panics(t, func() { WorkerPoolAsyncPriority(func(v int) int { return v }, 0, 0) })
+}
+
+func TestWorkerPoolAsyncPriorityAging(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		release := make(chan bool)
+		var order []string
+		async, stop := WorkerPoolAsyncPriority(func(v string) string {
+			if v == "blocker" {
+				<-release
+			}
+			order = append(order, v)
+			return v
+		}, 1, time.Second)
+		async("blocker", 100)
+		synctest.Wait()
+		async("old", 0)
+		time.Sleep(time.Second * 10)
+		// old one waited 10 intervals so it outranks newer job with priority 5, but not one with 20
+		async("new", 5)
+		async("urgent", 20)
+		close(release)
+		stop()
+		assert.Equal(t, []string{"blocker", "urgent", "old", "new"}, order)
+	})
+}
+
+func TestWorkerPoolCtx(t *testing.T) {
+	t.Run("finishes", func(t *testing.T) {
+		in := GenChanN(func(idx int) int { return idx }, 32, true)
+		out := make(chan string, 2)
+		finished := make(chan error, 1)
+		go func() {
+			finished <- WorkerPoolCtx(context.Background(), in, out, func(_ context.Context, i int) string {
+				return strconv.Itoa(i)
+			}, 4, true)
+		}()
+		outSlice := ChanToSlice(out)
+		assert.NoError(t, <-finished)
+		assert.True(t, CompareSliceSet(
+			GenSlice(32, func(idx int) string { return strconv.Itoa(idx) }),
+			outSlice))
+		assert.Panics(t, func() {
+			WorkerPoolCtx(context.Background(), in, out, func(_ context.Context, i int) string {
+				return strconv.Itoa(i)
+			}, 0)
+		})
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := GenChan(func() int { return 1 })
+		// nobody reads output so workers get stuck on send
+		out := make(chan int)
+		finished := make(chan error, 1)
+		go func() {
+			finished <- WorkerPoolCtx(ctx, in, out, func(_ context.Context, i int) int { return i }, 4, true)
+		}()
+		cancel()
+		select {
+		case err := <-finished:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("pool did not stop after cancel")
+		}
+		_, ok := <-out
+		assert.False(t, ok, "output should be closed")
+	})
+}
+
+func TestWorkerPoolCtxBackground(t *testing.T) {
+	in := GenChanN(func(idx int) int { return idx }, 32, true)
+	out := WorkerPoolCtxBackground(context.Background(), in, func(_ context.Context, i int) string {
+		return strconv.Itoa(i)
+	}, 4, true)
+	assert.Len(t, ChanToSlice(out), 32)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out = WorkerPoolCtxBackground(ctx, GenChan(func() int { return 1 }), func(_ context.Context, i int) string {
+		return strconv.Itoa(i)
+	}, 4, true)
+	assert.Equal(t, "1", <-out)
+	cancel()
+	// drains what was in flight, then closes
+	ChanToSlice(out)
+	assert.Panics(t, func() {
+		WorkerPoolCtxBackground(ctx, in, func(_ context.Context, i int) string { return "" }, 0)
+	})
+}
+
+func TestWorkerPoolCtxFinisher(t *testing.T) {
+	in := GenChanN(func(idx int) int { return idx }, 32, true)
+	out := make(chan string, 2)
+	finisher := WorkerPoolCtxFinisher(context.Background(), in, out, func(_ context.Context, i int) string {
+		return strconv.Itoa(i)
+	}, 4)
+	assert.Len(t, ChanToSlice(out), 32)
+	assert.NoError(t, <-finisher)
+	_, ok := <-finisher
+	assert.False(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out = make(chan string)
+	finisher = WorkerPoolCtxFinisher(ctx, GenChan(func() int { return 1 }), out, func(_ context.Context, i int) string {
+		return strconv.Itoa(i)
+	}, 4)
+	cancel()
+	assert.ErrorIs(t, <-finisher, context.Canceled)
+}
+
+func TestWorkerPoolCtxDrain(t *testing.T) {
+	c := make(chan int, 1)
+	sum := make(chan int, 4)
+	finish := WorkerPoolCtxDrain(context.Background(), func(_ context.Context, i int) { sum <- i }, 4, c)
+	c <- 1
+	c <- 2
+	close(c)
+	assert.NoError(t, <-finish)
+	assert.Equal(t, 3, <-sum+<-sum)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finish = WorkerPoolCtxDrain(ctx, func(ctx context.Context, i int) { <-ctx.Done() }, 2, GenChan(func() int { return 1 }))
+	cancel()
+	assert.ErrorIs(t, <-finish, context.Canceled)
+}
+
+func TestWorkerPoolCtxAsync(t *testing.T) {
+	async, stop := WorkerPoolCtxAsync(context.Background(), func(_ context.Context, i int) string { return strconv.Itoa(i) }, 2)
+	a1, err := async(1)
+	assert.NoError(t, err)
+	a2, err := async(2)
+	assert.NoError(t, err)
+	assert.NoError(t, stop())
+	assert.Equal(t, "1", <-a1)
+	assert.Equal(t, "2", <-a2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	block := make(chan bool)
+	asyncInt, stopInt := WorkerPoolCtxAsync(ctx, func(ctx context.Context, i int) int {
+		<-block
+		return i
+	}, 1)
+	_, err = asyncInt(1)
+	assert.NoError(t, err)
+	j2, err := asyncInt(2)
+	assert.NoError(t, err)
+	cancel()
+	close(block)
+	_, err = asyncInt(3)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, stopInt(), context.Canceled)
+	// second job was queued behind the first one, so it never ran
+	_, ok := <-j2
+	assert.False(t, ok)
+}
+
+func TestWorkerPoolErr(t *testing.T) {
+	in := GenChanN(func(idx int) int { return idx }, 32, true)
+	out := make(chan string, 2)
+	finished := make(chan error, 1)
+	go func() {
+		finished <- WorkerPoolErr(context.Background(), in, out, func(_ context.Context, i int) (string, error) {
+			return strconv.Itoa(i), nil
+		}, 4, true)
+	}()
+	assert.Len(t, ChanToSlice(out), 32)
+	assert.NoError(t, <-finished)
+
+	failErr := errors.New("fail")
+	in = GenChan(func() int { return 1 })
+	out = make(chan string, 2)
+	go func() {
+		finished <- WorkerPoolErr(context.Background(), in, out, func(ctx context.Context, i int) (string, error) {
+			if i == 1 {
+				return "", failErr
+			}
+			return strconv.Itoa(i), nil
+		}, 4, true)
+	}()
+	assert.Empty(t, ChanToSlice(out))
+	assert.ErrorIs(t, <-finished, failErr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t,
+		WorkerPoolErr(ctx, GenChan(func() int { return 1 }), make(chan int), func(_ context.Context, i int) (int, error) {
+			return i, nil
+		}, 2),
+		context.Canceled)
+	assert.Panics(t, func() {
+		WorkerPoolErr(ctx, in, out, func(_ context.Context, i int) (string, error) { return "", nil }, 0)
+	})
+}
+
+func TestWorkerPoolErrAll(t *testing.T) {
+	in := GenChanN(func(idx int) int { return idx }, 32, true)
+	out := make(chan int, 2)
+	finished := make(chan error, 1)
+	go func() {
+		finished <- WorkerPoolErrAll(context.Background(), in, out, func(_ context.Context, i int) (int, error) {
+			if i%10 == 0 {
+				return 0, fmt.Errorf("err %d", i)
+			}
+			return i, nil
+		}, 4, true)
+	}()
+	assert.Len(t, ChanToSlice(out), 28)
+	err := <-finished
+	assert.Error(t, err)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 4)
+	assert.Panics(t, func() {
+		WorkerPoolErrAll(context.Background(), in, out, func(_ context.Context, i int) (int, error) { return i, nil }, 0)
+	})
+}
+
+func TestWorkerPoolUnpanic(t *testing.T) {
+	in := GenChanN(func(idx int) int { return idx }, 32, true)
+	out := make(chan int, 2)
+	finished := make(chan error, 1)
+	go func() {
+		finished <- WorkerPoolUnpanic(in, out, func(i int) int {
+			if i == 7 {
+				panic("seven")
+			}
+			return i
+		}, 4, true)
+	}()
+	assert.Len(t, ChanToSlice(out), 31)
+	err := <-finished
+	var pe *PanicError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, 7, pe.Key)
+		assert.Equal(t, "seven", pe.Value)
+		assert.NotEmpty(t, pe.Stack)
+	}
+}
+
+func TestWorkerPoolBackgroundUnpanic(t *testing.T) {
+	in := GenChanN(func(idx int) int { return idx }, 32, true)
+	out, errCh := WorkerPoolBackgroundUnpanic(in, func(i int) int {
+		if i%8 == 0 {
+			panic(i)
+		}
+		return i
+	}, 4, true)
+	errs := make(chan []error, 1)
+	go func() { errs <- ChanToSlice(errCh) }()
+	assert.Len(t, ChanToSlice(out), 28)
+	keys := MapSlice(func(err error) any { return err.(*PanicError).Key }, <-errs)
+	assert.True(t, CompareSliceSet([]any{0, 8, 16, 24}, keys))
+	assert.Panics(t, func() {
+		WorkerPoolBackgroundUnpanic(in, func(i int) int { return i }, 0)
+	})
+}
+
+func TestWorkerPoolDrainUnpanic(t *testing.T) {
+	in := GenChanN(func(idx int) int { return idx }, 8, true)
+	finish := WorkerPoolDrainUnpanic(func(i int) {
+		if i == 3 {
+			panic("three")
+		}
+	}, 2, in)
+	err := <-finish
+	var pe *PanicError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, 3, pe.Key)
+	}
+
+	finish = WorkerPoolDrainUnpanic(func(i int) {}, 2, GenChanN(func(idx int) int { return idx }, 8, true))
+	assert.NoError(t, <-finish)
+}
+
+func TestWorkerPoolFinisherUnpanic(t *testing.T) {
+	in := GenChanN(func(idx int) int { return idx }, 16, true)
+	out := make(chan int, 2)
+	finisher := WorkerPoolFinisherUnpanic(in, out, func(i int) int {
+		if i == 5 {
+			panic("five")
+		}
+		return i
+	}, 3)
+	assert.Len(t, ChanToSlice(out), 15)
+	err := <-finisher
+	var pe *PanicError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, 5, pe.Key)
+	}
+	_, ok := <-finisher
+	assert.False(t, ok)
+	assert.Panics(t, func() { WorkerPoolFinisherUnpanic(in, out, func(i int) int { return i }, 0) })
+}
+
+func TestWorkerPoolAsyncUnpanic(t *testing.T) {
+	async, stop := WorkerPoolAsyncUnpanic(func(i int) int {
+		if i == 2 {
+			panic("two")
+		}
+		return i * 10
+	}, 2)
+	ch1 := async(1)
+	ch2 := async(2)
+	ch3 := async(3)
+	assert.Equal(t, 10, <-ch1)
+	_, ok := <-ch2
+	assert.False(t, ok, "panicked job should close its channel")
+	assert.Equal(t, 30, <-ch3)
+	err := stop()
+	var pe *PanicError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, 2, pe.Key)
+		assert.Equal(t, "two", pe.Value)
+	}
+	_, stop = WorkerPoolAsyncUnpanic(func(i int) int { return i }, 1)
+	assert.NoError(t, stop())
+	assert.Panics(t, func() { WorkerPoolAsyncUnpanic(func(i int) int { return i }, 0) })
+}